@@ -16,7 +16,7 @@ var downCmd = &cobra.Command{
 	Short: "Discard changes. Invokes down-migration in provided file",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		err := config.New(dbURL, 30, false).Configure(context.Background())
+		err := config.New(dbURL, 30, false, connectionOptions()...).Configure(context.Background())
 		if err != nil {
 			slog.Error("cannot initialize config", "error", err.Error())
 			return
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"github.com/gulfstream-h/ksql/config"
 	"github.com/joho/godotenv"
 	"log/slog"
 	"os"
@@ -8,26 +9,82 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var dbURL string
+var (
+	dbURL      string
+	dbUser     string
+	dbPassword string
+	dbToken    string
+	dbCert     string
+	dbKey      string
+	dbCA       string
+)
+
+// envBindings - pairs of command flags and .env keys.
+// Flag value has priority over .env file
+var envBindings = []struct {
+	flag  string
+	env   string
+	value *string
+}{
+	{flag: "db_url", env: "KSQL_DB_URL", value: &dbURL},
+	{flag: "user", env: "KSQL_DB_USER", value: &dbUser},
+	{flag: "password", env: "KSQL_DB_PASSWORD", value: &dbPassword},
+	{flag: "token", env: "KSQL_DB_TOKEN", value: &dbToken},
+	{flag: "cert", env: "KSQL_DB_CERT", value: &dbCert},
+	{flag: "key", env: "KSQL_DB_KEY", value: &dbKey},
+	{flag: "ca", env: "KSQL_DB_CA", value: &dbCA},
+}
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use: "ksql",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		// if ksql_url is not provided in command via --db_url tag, seeking in .env file
-		if !cmd.Flags().Changed("db_url") {
-			if err := godotenv.Load(); err != nil {
-				slog.Error("cannot load .env file", "error", err)
+		// flags, that are not provided in command, are seeking in .env file.
+		// Missing file is an error only if ksql_url is not provided via --db_url tag
+		if err := godotenv.Load(); err != nil && !cmd.Flags().Changed("db_url") {
+			slog.Error("cannot load .env file", "error", err)
+		}
+
+		for _, binding := range envBindings {
+			if cmd.Flags().Changed(binding.flag) {
+				continue
 			}
 
-			if env := os.Getenv("KSQL_DB_URL"); env != "" {
-				dbURL = env
+			if env := os.Getenv(binding.env); env != "" {
+				*binding.value = env
 			}
 		}
 	},
 	Short: "Migration tool to KSQL-server",
 }
 
+// connectionOptions - converts provided credentials
+// flags into config options. Both user and token
+// are passed, so config rejects conflicting auth
+func connectionOptions() []config.Option {
+	var (
+		opts []config.Option
+	)
+
+	if dbUser != "" {
+		opts = append(opts, config.WithBasicAuth(dbUser, dbPassword))
+	}
+
+	if dbToken != "" {
+		opts = append(opts, config.WithBearerToken(dbToken))
+	}
+
+	if dbCert != "" || dbKey != "" {
+		opts = append(opts, config.WithClientCertificate(dbCert, dbKey))
+	}
+
+	if dbCA != "" {
+		opts = append(opts, config.WithCertificateAuthority(dbCA))
+	}
+
+	return opts
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -38,8 +95,15 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.
-		PersistentFlags().
-		StringVar(&dbURL, "db_url", "", "database URL")
+	flags := rootCmd.PersistentFlags()
+
+	flags.StringVar(&dbURL, "db_url", "", "database URL")
+	flags.StringVar(&dbUser, "user", "", "basic auth username or API key, excludes --token")
+	flags.StringVar(&dbPassword, "password", "", "basic auth password or API secret")
+	flags.StringVar(&dbToken, "token", "", "bearer token, excludes --user")
+	flags.StringVar(&dbCert, "cert", "", "client certificate file for mTLS")
+	flags.StringVar(&dbKey, "key", "", "client certificate key file for mTLS")
+	flags.StringVar(&dbCA, "ca", "", "certificate authority file")
+
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
@@ -2,19 +2,18 @@ package config
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"github.com/gulfstream-h/ksql/database"
 	"github.com/gulfstream-h/ksql/errors"
 	"github.com/gulfstream-h/ksql/internal/kernel/network"
 	"github.com/gulfstream-h/ksql/shared"
 	"github.com/gulfstream-h/ksql/static"
-	"sync"
+	"log/slog"
+	"maps"
 	"time"
 )
 
-var (
-	once sync.Once
-)
-
 // config - is user defined structure
 // aimed to set exact settings for client
 type config struct {
@@ -22,20 +21,182 @@ type config struct {
 	TimeoutSec     int64  // request timeout in seconds
 	reflectionFlag bool
 	shared.Linter  // enables query lintering with reflection
+
+	auth      network.Authenticator // credentials for secured clusters
+	authCount int                   // count of applied auth options
+	tlsConfig *tls.Config           // user provided tls settings
+	certFile  string                // client certificate for mTLS
+	keyFile   string                // client certificate key for mTLS
+	caFile    string                // custom certificate authority
+	retry     RetryPolicy           // repeating of idempotent requests
+
+	properties network.Properties // defaults of every request
+
+	interceptors []shared.Interceptor // observers of every statement
+	logger       *slog.Logger         // destination of library logs
+
+	endpoints   []string      // additional nodes of the same cluster
+	healthCheck time.Duration // nodes probing interval
+}
+
+const (
+	// defaultHealthCheck - probing interval of multi-node cluster
+	defaultHealthCheck = 10 * time.Second
+)
+
+// Option - applies optional connection settings to config
+type Option func(cfg *config)
+
+// TokenSource - provides bearer token with its expiration time.
+// Zero expiration means that token is requested for every call
+type TokenSource = network.TokenSource
+
+// RetryPolicy - describes how requests are repeated on
+// temporary failures: connection errors and listed statuses.
+// Reads are retried automatically, other statements only
+// with database.WithRetry call option
+type RetryPolicy = network.RetryPolicy
+
+// DefaultRetryPolicy - policy, used when none is provided
+func DefaultRetryPolicy() RetryPolicy {
+	return network.DefaultRetryPolicy()
+}
+
+// WithRetryPolicy - replaces default retry policy.
+// Zero policy disables retries
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(cfg *config) {
+		cfg.retry = policy
+	}
+}
+
+// WithStreamsProperties - sets streams properties, sent with every
+// request of client. Call options with the same keys take precedence
+func WithStreamsProperties(properties map[string]any) Option {
+	return func(cfg *config) {
+		cfg.properties.Streams = maps.Clone(properties)
+	}
+}
+
+// WithSessionVariables - sets session variables, sent with every
+// request of client. Call options with the same names take precedence
+func WithSessionVariables(variables map[string]any) Option {
+	return func(cfg *config) {
+		cfg.properties.Variables = maps.Clone(variables)
+	}
+}
+
+// WithInterceptors - registers observers of every statement,
+// e.g. tracing and metrics adapters. Interceptors are called
+// in registration order before request and reverse after it
+func WithInterceptors(interceptors ...shared.Interceptor) Option {
+	return func(cfg *config) {
+		cfg.interceptors = append(cfg.interceptors, interceptors...)
+	}
+}
+
+// WithLogger - routes library logs to provided logger
+// instead of default one. Statements are logged at debug
+// level with literals redacted, payloads are never logged
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *config) {
+		cfg.logger = logger
+	}
+}
+
+// WithEndpoints - adds other nodes of the same cluster.
+// Requests are sent to single healthy node and fail over
+// to next one, when it stops responding
+func WithEndpoints(hosts ...string) Option {
+	return func(cfg *config) {
+		cfg.endpoints = append(cfg.endpoints, hosts...)
+	}
+}
+
+// WithHealthCheckInterval - sets how often nodes are probed
+// with /healthcheck and /clusterStatus. Zero disables probing,
+// then failed nodes are tried again after 30 seconds cooldown
+func WithHealthCheckInterval(interval time.Duration) Option {
+	return func(cfg *config) {
+		cfg.healthCheck = interval
+	}
+}
+
+// WithBasicAuth - authenticates requests with username and password.
+// Confluent Cloud API key and secret are passed the same way.
+// It cannot be combined with other auth options
+func WithBasicAuth(username, password string) Option {
+	return func(cfg *config) {
+		cfg.setAuth(network.BasicAuth{
+			Username: username,
+			Password: password,
+		})
+	}
+}
+
+// WithBearerToken - authenticates requests with static bearer
+// token. It cannot be combined with other auth options
+func WithBearerToken(token string) Option {
+	return func(cfg *config) {
+		cfg.setAuth(network.BearerToken{
+			Token: token,
+		})
+	}
+}
+
+// WithTokenSource - authenticates requests with bearer token,
+// that is requested from source and renewed on expiration.
+// It cannot be combined with other auth options
+func WithTokenSource(source TokenSource) Option {
+	return func(cfg *config) {
+		cfg.setAuth(network.NewRefreshingToken(source))
+	}
+}
+
+// setAuth - applies credentials and counts them, so
+// conflicting auth options are rejected on connection
+func (cfg *config) setAuth(auth network.Authenticator) {
+	cfg.auth = auth
+	cfg.authCount++
+}
+
+// WithClientCertificate - enables mutual TLS with
+// PEM encoded certificate and private key files
+func WithClientCertificate(certFile, keyFile string) Option {
+	return func(cfg *config) {
+		cfg.certFile = certFile
+		cfg.keyFile = keyFile
+	}
+}
+
+// WithCertificateAuthority - verifies server certificate
+// with PEM encoded CA file instead of system pool
+func WithCertificateAuthority(caFile string) Option {
+	return func(cfg *config) {
+		cfg.caFile = caFile
+	}
+}
+
+// WithTLSConfig - sets fully custom tls settings.
+// Certificate files options are ignored, when it's provided
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(cfg *config) {
+		cfg.tlsConfig = tlsConfig
+	}
 }
 
 // New returns config file for ksql-connection
 // establishment. It's also configures reflection mode
-// in which library is executed
+// in which library is executed. Reflection flag is
+// process-wide default of query builders, which are
+// not bound to client with ksql.SelectBuilder.Reflect
 func New(
 	host string,
 	timeoutSec int64,
-	reflectionFlag bool) shared.Config {
+	reflectionFlag bool,
+	opts ...Option) shared.Config {
 
-	var cfg = config{
-		Host:       host,
-		TimeoutSec: timeoutSec,
-	}
+	cfg := newConfig(host, timeoutSec, reflectionFlag, opts...)
 
 	static.ReflectionFlag = reflectionFlag
 
@@ -45,31 +206,119 @@ func New(
 		cfg.Linter = _NoReflectionMode{}
 	}
 
+	return cfg
+}
+
+// newConfig - applies options over default settings
+func newConfig(
+	host string,
+	timeoutSec int64,
+	reflectionFlag bool,
+	opts ...Option,
+) *config {
+
+	var cfg = config{
+		Host:           host,
+		TimeoutSec:     timeoutSec,
+		reflectionFlag: reflectionFlag,
+		retry:          network.DefaultRetryPolicy(),
+		healthCheck:    defaultHealthCheck,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	return &cfg
 }
 
 // Configure - applying method for structures
-// it initialize network connection. Entry-point for library
+// it initialize network connection of default client,
+// used by package-level functions. Entry-point for library
 // net operations
-func (cfg *config) Configure(ctx context.Context) (err error) {
-	once.Do(func() {
-		if cfg.Host == "" {
-			err = errors.ErrMissingHost
-			return
-		}
+func (cfg *config) Configure(ctx context.Context) error {
+	client, err := cfg.connect(ctx, static.DefaultCache)
+	if err != nil {
+		return err
+	}
 
-		if cfg.TimeoutSec <= 0 {
-			err = errors.ErrTimeoutIsZeroOrNegative
-			return
-		}
+	// replaced client must stop its health checks
+	if previous := database.SetDefault(client); previous != client {
+		previous.Close()
+	}
+	return nil
+}
+
+// NewClient - creates independent client with its own
+// connection and reflection cache. It doesn't affect
+// default client, so one process can work with several clusters.
+// Client is passed to library functions with database.WithClient.
+// Its reflection doesn't change static.ReflectionFlag: library
+// functions bind client cache to query builders, own builders
+// are bound with Reflect(client.ReflectionCache())
+func NewClient(
+	ctx context.Context,
+	host string,
+	timeoutSec int64,
+	reflectionFlag bool,
+	opts ...Option,
+) (*database.Client, error) {
 
-		network.Init(cfg.Host, time.Duration(cfg.TimeoutSec)*time.Second)
+	cfg := newConfig(host, timeoutSec, reflectionFlag, opts...)
 
-		if err = cfg.Linter.InitLinter(ctx); err != nil {
-			err = fmt.Errorf("cannot run lintering: %w", err)
-			return
+	return cfg.connect(ctx, static.NewCache())
+}
+
+// connect - validates settings, establishes transport
+// and fills reflection cache of new client
+func (cfg *config) connect(
+	ctx context.Context,
+	cache *static.Cache,
+) (*database.Client, error) {
+
+	if cfg.Host == "" {
+		return nil, errors.ErrMissingHost
+	}
+
+	if cfg.TimeoutSec <= 0 {
+		return nil, errors.ErrTimeoutIsZeroOrNegative
+	}
+
+	if cfg.authCount > 1 {
+		return nil, errors.ErrConflictingAuth
+	}
+
+	tlsConfig := cfg.tlsConfig
+	if tlsConfig == nil && (cfg.certFile != "" || cfg.keyFile != "" || cfg.caFile != "") {
+		var err error
+		tlsConfig, err = network.NewTLSConfig(cfg.certFile, cfg.keyFile, cfg.caFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot build tls config: %w", err)
 		}
+	}
+
+	transport := network.New(network.Settings{
+		Hosts:       append([]string{cfg.Host}, cfg.endpoints...),
+		Timeout:     time.Duration(cfg.TimeoutSec) * time.Second,
+		Auth:        cfg.auth,
+		TLS:         tlsConfig,
+		Retry:       cfg.retry,
+		HealthCheck: cfg.healthCheck,
+		Properties:  cfg.properties,
+
+		Interceptors: cfg.interceptors,
+		Logger:       cfg.logger,
 	})
 
-	return nil
+	client := database.NewClient(transport, cfg.reflectionFlag, cache, cfg.logger)
+
+	if cfg.reflectionFlag {
+		linter := _ReflectionMode{client: client}
+		if err := linter.InitLinter(ctx); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("cannot run lintering: %w", err)
+		}
+	}
+
+	return client, nil
 }
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"context"
+	"github.com/gulfstream-h/ksql/errors"
+	"github.com/gulfstream-h/ksql/static"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_ConflictingAuth(t *testing.T) {
+	source := func(ctx context.Context) (string, time.Time, error) {
+		return "token", time.Time{}, nil
+	}
+
+	testcases := []struct {
+		name string
+		opts []Option
+	}{
+		{
+			name: "Basic auth and bearer token",
+			opts: []Option{WithBasicAuth("user", "secret"), WithBearerToken("token")},
+		},
+		{
+			name: "Bearer token and token source",
+			opts: []Option{WithBearerToken("token"), WithTokenSource(source)},
+		},
+		{
+			name: "Basic auth and token source",
+			opts: []Option{WithTokenSource(source), WithBasicAuth("user", "secret")},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newConfig("http://localhost:8088", 1, false, tc.opts...)
+
+			client, err := cfg.connect(context.Background(), static.NewCache())
+			assert.Nil(t, client)
+			assert.ErrorIs(t, err, errors.ErrConflictingAuth)
+		})
+	}
+}
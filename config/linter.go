@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"fmt"
+	"github.com/gulfstream-h/ksql/database"
 	"github.com/gulfstream-h/ksql/internal/schema"
 	"github.com/gulfstream-h/ksql/kinds"
 	"github.com/gulfstream-h/ksql/shared"
@@ -21,32 +22,44 @@ type (
 	// so query builder matches user-listed fields with cached storage.
 	// if field is not presented in cache or user-provided type mismatch with
 	// cashed value - reflection check will return an error before executing query
-	_ReflectionMode struct{}
+	_ReflectionMode struct {
+		client *database.Client // default client is used when nil
+	}
 )
 
 func (mode _NoReflectionMode) InitLinter(context.Context) error {
 	return nil
 }
 
-// InitLinter - caches streams & tables. Eventually, it changes global reflection variable
+// InitLinter - caches streams & tables of client. Global reflection
+// variable is changed only for default client, other clients
+// bind their caches to query builders
 func (mode _ReflectionMode) InitLinter(ctx context.Context) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	static.ReflectionFlag = true
-	slog.Debug("reflection mode is enabled")
+	if mode.client == nil {
+		static.ReflectionFlag = true
+	}
+
+	var (
+		client = database.ClientOf(database.WithClient(mode.client))
+		opt    = database.WithClient(client)
+		logger = client.Logger()
+	)
+
+	logger.Debug("reflection mode is enabled")
 
-	streamList, err := streams.ListStreams(ctx)
+	streamList, err := streams.ListStreams(ctx, opt)
 	if err != nil {
 		return fmt.Errorf("cannot list streams: %w", err)
 	}
 
-	slog.Debug("streams listed!", "list", streamList)
+	logger.Debug("streams listed", slog.Int("count", len(streamList.Streams)))
 
 	for _, stream := range streamList.Streams {
-		description, err := streams.Describe(ctx, stream.Name)
+		description, err := streams.Describe(ctx, stream.Name, opt)
 		if err != nil {
-			slog.Error("cannot describe stream", "error", err.Error())
 			return fmt.Errorf("cannot describe stream: %w", err)
 		}
 
@@ -58,23 +71,22 @@ func (mode _ReflectionMode) InitLinter(ctx context.Context) error {
 			responseSchema[field.Name] = field.Kind
 		}
 
-		static.StreamsProjections.Set(stream.Name, shared.StreamSettings{
+		client.Cache().Streams.Set(stream.Name, shared.StreamSettings{
 			SourceTopic: stream.Topic,
 			ValueFormat: kinds.JSON,
 		}, schema.RemoteFieldsRepresentation(stream.Name, responseSchema))
 	}
 
-	tableList, err := tables.ListTables(ctx)
+	tableList, err := tables.ListTables(ctx, opt)
 	if err != nil {
 		return fmt.Errorf("cannot list tables: %w", err)
 	}
 
-	slog.Debug("tables listed!", "list", tableList)
+	logger.Debug("tables listed", slog.Int("count", len(tableList.Tables)))
 
 	for _, table := range tableList.Tables {
-		description, err := streams.Describe(ctx, table.Name)
+		description, err := streams.Describe(ctx, table.Name, opt)
 		if err != nil {
-			slog.Error("cannot describe table", "error", err.Error())
 			return fmt.Errorf("cannot describe table: %w", err)
 		}
 
@@ -86,7 +98,7 @@ func (mode _ReflectionMode) InitLinter(ctx context.Context) error {
 			responseSchema[field.Name] = field.Kind
 		}
 
-		static.StreamsProjections.Set(table.Name, shared.StreamSettings{
+		client.Cache().Streams.Set(table.Name, shared.StreamSettings{
 			SourceTopic: table.Topic,
 			ValueFormat: kinds.JSON,
 		}, schema.RemoteFieldsRepresentation(table.Name, responseSchema))
@@ -5,8 +5,12 @@ const (
 )
 
 const (
-	KsqlRoute  = "/ksql"  // used for http mode
-	QueryRoute = "/query" // used for websocket mode
+	KsqlRoute        = "/ksql"          // used for http mode
+	QueryRoute       = "/query"         // used for websocket mode
+	QueryStreamRoute = "/query-stream"  // used for http/2 streaming mode
+	CloseQueryRoute  = "/close-query"   // used for push queries termination
+	HealthCheckRoute = "/healthcheck"   // used for node liveness probes
+	ClusterRoute     = "/clusterStatus" // used for cluster-wide liveness
 )
 
 const (
@@ -18,6 +22,9 @@ const (
 )
 
 const (
-	ContentType = "Content-Type"                 // http Header-Name
-	HeaderKSQL  = "application/vnd.ksql.v1+json" // ksql Header
+	ContentType     = "Content-Type"                         // http Header-Name
+	Accept          = "Accept"                               // http Header-Name
+	HeaderKSQL      = "application/vnd.ksql.v1+json"         // ksql Header
+	HeaderJSON      = "application/json"                     // plain json Header
+	HeaderDelimited = "application/vnd.ksqlapi.delimited.v1" // ksql http/2 framing Header
 )
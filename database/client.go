@@ -0,0 +1,227 @@
+package database
+
+import (
+	"context"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/gulfstream-h/ksql/internal/kernel/network"
+	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dao"
+	"github.com/gulfstream-h/ksql/internal/util"
+	"github.com/gulfstream-h/ksql/static"
+	jsoniter "github.com/json-iterator/go"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+)
+
+// Client - owns connection to single ksqlDB cluster,
+// its reflection cache and settings. Independent clients
+// can be used in one process to reach different clusters
+type Client struct {
+	net        *network.Transport
+	reflection bool
+	cache      *static.Cache
+	logger     *slog.Logger
+
+	// sequence - highest command sequence number, received
+	// from server. It's sent with following requests, so every
+	// node applies previous commands before executing new ones
+	sequence atomic.Int64
+}
+
+var (
+	// defaultClient - is used by package-level functions
+	// when no client is passed with WithClient option
+	defaultClient atomic.Pointer[Client]
+)
+
+// NewClient - assembles client from configured transport.
+// Library users should build clients with config.NewClient
+func NewClient(
+	transport *network.Transport,
+	reflection bool,
+	cache *static.Cache,
+	logger *slog.Logger,
+) *Client {
+	if cache == nil {
+		cache = static.NewCache()
+	}
+
+	return &Client{
+		net:        transport,
+		reflection: reflection,
+		cache:      cache,
+		logger:     logger,
+	}
+}
+
+// SetDefault - replaces client, used by package-level
+// functions. Returns previous client, so it can be closed
+func SetDefault(client *Client) *Client {
+	return defaultClient.Swap(client)
+}
+
+// Default - returns client, used by package-level functions.
+// It's nil until config.Configure is called
+func Default() *Client {
+	return defaultClient.Load()
+}
+
+// Close - stops background work of client,
+// such as cluster nodes health checking
+func (c *Client) Close() {
+	if c != nil {
+		c.net.Close()
+	}
+}
+
+// Logger - returns logger of client. When it's not
+// configured, default slog logger is used.
+// Library logs follow the policy:
+//   - Error - failures, that cannot be returned to caller (background streams)
+//   - Warn  - degraded operation, e.g. cluster node failover
+//   - Info  - lifecycle events, e.g. push query reconnection
+//   - Debug - redacted statements, raw payloads are never logged
+func (c *Client) Logger() *slog.Logger {
+	if c == nil || c.logger == nil {
+		return slog.Default()
+	}
+	return c.logger
+}
+
+// Reflection - reports if queries of client
+// are checked with cached relations
+func (c *Client) Reflection() bool {
+	return c != nil && c.reflection
+}
+
+// Cache - returns reflection cache of client
+func (c *Client) Cache() *static.Cache {
+	if c == nil {
+		return static.DefaultCache
+	}
+	return c.cache
+}
+
+// ReflectionCache - returns cache, which queries of client
+// are checked with, or nil, if reflection is disabled
+func (c *Client) ReflectionCache() *static.Cache {
+	if !c.Reflection() {
+		return nil
+	}
+	return c.cache
+}
+
+// CommandSequence - returns highest command
+// sequence number, received by client
+func (c *Client) CommandSequence() int64 {
+	if c == nil {
+		return 0
+	}
+	return c.sequence.Load()
+}
+
+// observe - remembers command sequence number of response
+func (c *Client) observe(response []byte) {
+	var (
+		commands []dao.CommandSequence
+	)
+
+	// error and non-command responses are skipped
+	if err := jsoniter.Unmarshal(response, &commands); err != nil {
+		return
+	}
+
+	for _, command := range commands {
+		for {
+			current := c.sequence.Load()
+			if command.CommandSequenceNumber <= current ||
+				c.sequence.CompareAndSwap(current, command.CommandSequenceNumber) {
+				break
+			}
+		}
+	}
+}
+
+// Perform - sends ksql statement to /ksql endpoint
+// and returns pipeline with raw server response.
+// Read statements and statements with WithRetry
+// option are repeated on temporary failures
+func (c *Client) Perform(
+	ctx context.Context,
+	query string,
+	opts ...Option,
+) (<-chan []byte, error) {
+
+	if c == nil {
+		return nil, libErrors.ErrClientNotConfigured
+	}
+
+	var (
+		pipeline <-chan []byte
+		o        = newOptions(opts...)
+	)
+
+	if o.properties.CommandSequence == 0 {
+		o.properties.CommandSequence = c.sequence.Load()
+	}
+
+	query, err := o.bind(query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Logger().Debug("ksql statement", slog.Any("statement", util.Statement(query)))
+
+	perform := func() (err error) {
+		pipeline, err = c.net.Perform(
+			ctx,
+			http.MethodPost,
+			query,
+			o.properties,
+			network.ShortPolling{},
+		)
+		return err
+	}
+
+	if o.retry || isReadStatement(query) {
+		err = c.net.Retry(ctx, perform)
+	} else {
+		err = perform()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := make(chan []byte, 1)
+
+	go func() {
+		defer close(tracked)
+
+		for response := range pipeline {
+			c.observe(response)
+			tracked <- response
+		}
+	}()
+
+	return tracked, nil
+}
+
+// Execute - performs any ksql statement and returns raw server response
+func (c *Client) Execute(
+	ctx context.Context,
+	query string,
+	opts ...Option,
+) (string, error) {
+
+	response, err := c.Perform(ctx, query, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case msg := <-response:
+		return string(msg), nil
+	}
+}
@@ -0,0 +1,259 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"github.com/gulfstream-h/ksql/consts"
+	"github.com/gulfstream-h/ksql/internal/kernel/network"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_ClientObserve(t *testing.T) {
+	testcases := []struct {
+		name      string
+		responses []string
+		expected  int64
+	}{
+		{
+			name:      "Command response",
+			responses: []string{`[{"@type":"currentStatus","commandSequenceNumber":4}]`},
+			expected:  4,
+		},
+		{
+			name: "Highest number is kept",
+			responses: []string{
+				`[{"@type":"currentStatus","commandSequenceNumber":7}]`,
+				`[{"@type":"currentStatus","commandSequenceNumber":3}]`,
+			},
+			expected: 7,
+		},
+		{
+			name:      "Several commands in response",
+			responses: []string{`[{"commandSequenceNumber":2},{"commandSequenceNumber":9},{"commandSequenceNumber":5}]`},
+			expected:  9,
+		},
+		{
+			name: "Error and non-command responses are skipped",
+			responses: []string{
+				`[{"commandSequenceNumber":2}]`,
+				`{"@type":"statement_error","error_code":40001,"message":"Syntax Error"}`,
+				`[{"@type":"streams","streams":[]}]`,
+			},
+			expected: 2,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &Client{}
+
+			for _, response := range tc.responses {
+				client.observe([]byte(response))
+			}
+
+			assert.Equal(t, tc.expected, client.CommandSequence())
+		})
+	}
+}
+
+func Test_ClientObserveConcurrent(t *testing.T) {
+	var (
+		client = &Client{}
+		wg     sync.WaitGroup
+	)
+
+	for sequence := 1; sequence <= 100; sequence++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.observe(fmt.Appendf(nil, `[{"commandSequenceNumber":%d}]`, sequence))
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, int64(100), client.CommandSequence())
+}
+
+func Test_ClientPerformSequence(t *testing.T) {
+	var (
+		sequences = make(chan int64, 1)
+	)
+
+	client := streamClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			body struct {
+				KSQL     string `json:"ksql"`
+				Sequence *int64 `json:"commandSequenceNumber"`
+			}
+		)
+
+		if err := jsoniter.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if body.Sequence == nil {
+			sequences <- -1
+		} else {
+			sequences <- *body.Sequence
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"@type":"currentStatus","commandSequenceNumber":12}]`))
+	}))
+
+	testcases := []struct {
+		name     string
+		opts     []Option
+		expected int64
+	}{
+		{
+			name:     "First statement omits sequence",
+			expected: -1,
+		},
+		{
+			name:     "Received sequence is sent",
+			expected: 12,
+		},
+		{
+			name:     "Sequence is overridden per call",
+			opts:     []Option{WithCommandSequence(30)},
+			expected: 30,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.Execute(context.Background(), "CREATE STREAM S (ID INT) WITH (KAFKA_TOPIC='s', VALUE_FORMAT='JSON');", tc.opts...)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, <-sequences)
+		})
+	}
+}
+
+func Test_ClientProperties(t *testing.T) {
+	var (
+		bodies = make(chan map[string]any, 1)
+	)
+
+	server := streamServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			body map[string]any
+		)
+
+		if err := jsoniter.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		bodies <- body
+
+		switch r.URL.Path {
+		case consts.KsqlRoute:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`[{"@type":"currentStatus"}]`))
+		case consts.QueryStreamRoute:
+			w.Header().Set(consts.ContentType, consts.HeaderDelimited)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"queryId":"query_1","columnNames":["ID"],"columnTypes":["INTEGER"]}` + "\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	client := NewClient(network.New(network.Settings{
+		Hosts:   []string{server.URL},
+		Timeout: time.Second,
+		Properties: network.Properties{
+			Streams: map[string]any{
+				AutoOffsetReset:                      "latest",
+				"ksql.query.pull.table.scan.enabled": true,
+			},
+			Variables: map[string]any{
+				"topic": "orders",
+			},
+		},
+	}), false, nil, nil)
+	t.Cleanup(client.Close)
+
+	testcases := []struct {
+		name          string
+		opts          []Option
+		wantStreams   map[string]any
+		wantVariables map[string]any
+	}{
+		{
+			name: "Client defaults",
+			wantStreams: map[string]any{
+				AutoOffsetReset:                      "latest",
+				"ksql.query.pull.table.scan.enabled": true,
+			},
+			wantVariables: map[string]any{
+				"topic": "orders",
+			},
+		},
+		{
+			name: "Call options override defaults",
+			opts: []Option{
+				WithProperty(AutoOffsetReset, "earliest"),
+				WithSessionVariable("topic", "payments"),
+			},
+			wantStreams: map[string]any{
+				AutoOffsetReset:                      "earliest",
+				"ksql.query.pull.table.scan.enabled": true,
+			},
+			wantVariables: map[string]any{
+				"topic": "payments",
+			},
+		},
+		{
+			name: "Call options extend defaults",
+			opts: []Option{
+				WithProperties(map[string]any{"ksql.streams.num.stream.threads": float64(2)}),
+				WithSessionVariables(map[string]any{"format": "JSON", "replicas": float64(3)}),
+			},
+			wantStreams: map[string]any{
+				AutoOffsetReset:                      "latest",
+				"ksql.query.pull.table.scan.enabled": true,
+				"ksql.streams.num.stream.threads":    float64(2),
+			},
+			wantVariables: map[string]any{
+				"topic":    "orders",
+				"format":   "JSON",
+				"replicas": float64(3),
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_, err := client.Execute(ctx, "LIST STREAMS;", tc.opts...)
+			assert.NoError(t, err)
+
+			body := <-bodies
+			assert.Equal(t, tc.wantStreams, body["streamsProperties"])
+			assert.Equal(t, tc.wantVariables, body["sessionVariables"])
+
+			result, err := Select[struct {
+				ID int `ksql:"ID"`
+			}](ctx, "SELECT ID FROM ORDERS_TABLE WHERE ID = 1;", append(tc.opts, WithClient(client))...)
+			assert.NoError(t, err)
+
+			body = <-bodies
+			assert.Equal(t, tc.wantStreams, body["properties"])
+			assert.Equal(t, tc.wantVariables, body["sessionVariables"])
+
+			for range result.Values() {
+			}
+			assert.Equal(t, Completed, result.Reason())
+		})
+	}
+}
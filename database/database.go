@@ -2,113 +2,425 @@ package database
 
 import (
 	"context"
+	"errors"
+	libErrors "github.com/gulfstream-h/ksql/errors"
 	"github.com/gulfstream-h/ksql/internal/kernel/network"
 	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dao"
 	"github.com/gulfstream-h/ksql/internal/schema/netparse"
+	"github.com/gulfstream-h/ksql/internal/util"
 	jsoniter "github.com/json-iterator/go"
 	"log/slog"
-	"net/http"
-	"strings"
 )
 
+// Execute - performs any ksql statement with default
+// or passed by WithClient option client
 func Execute(
 	ctx context.Context,
 	query string,
+	opts ...Option,
 ) (string, error) {
-	response, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		network.ShortPolling{},
-	)
-	if err != nil {
-		return "", err
-	}
-
-	select {
-	case <-ctx.Done():
-		return "", ctx.Err()
-	case msg := <-response:
-		return string(msg), nil
-	}
+	return ClientOf(opts...).Execute(ctx, query, opts...)
 }
 
+// Select - performs select query over http/2 /query-stream
+// endpoint and returns handle of running query. Every received
+// row is propagated to Result.Values channel. Channel is closed
+// when query is completed, failed or closed. Push queries,
+// whose connection is broken, are re-issued on healthy node
+// once per drop, or according to reconnect policy (see Subscribe).
+// Push query, finished by server, is completed.
+// Values are buffered according to WithBuffer option, by
+// default reader waits for consumer. Result must be closed
+// or context cancelled, when consumer stops reading
 func Select[S any](
 	ctx context.Context,
 	query string,
-) (<-chan S, error) {
+	opts ...Option,
+) (*Result[S], error) {
 
-	valuesC := make(chan S)
+	client := ClientOf(opts...)
+	if client == nil {
+		return nil, libErrors.ErrClientNotConfigured
+	}
+
+	// pull queries are finite reads, so they are safe to repeat.
+	// Push queries are repeated only on demand
+	o := newOptions(opts...)
+
+	query, err := o.bind(query)
+	if err != nil {
+		return nil, err
+	}
 
-	response, err := network.Net.PerformSelect(
+	ctx, cancel := context.WithCancel(ctx)
+
+	stream, err := client.openStream(
 		ctx,
-		http.MethodPost,
 		query,
-		network.LongPolling{},
+		o.properties,
+		isPullQuery(query) || o.retry,
 	)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
 
+	result := &Result[S]{
+		net:      client.net,
+		values:   make(chan S, o.bufferSize()),
+		cancel:   cancel,
+		overflow: o.overflow,
+	}
+	result.attach(stream)
+
 	go func() {
+		// finished query must not hold connection
+		// and keep running on server side
+		defer func() {
+			if err := result.Close(); err != nil {
+				client.Logger().Debug(
+					"finished query is not closed",
+					slog.String("id", result.ID()),
+					slog.String("error", err.Error()),
+				)
+			}
+		}()
+		defer close(result.values)
+
 		var (
-			iter    = 0
-			headers dao.Header
+			attempts int
 		)
 
 		for {
-			select {
-			case <-ctx.Done():
-				close(valuesC)
+			rows, dropErr := readStream(ctx, client.Logger(), result, stream)
+			if dropErr == nil {
 				return
-			case val, ok := <-response:
-				if !ok {
-					close(valuesC)
+			}
+
+			// connection of push query is dropped, query
+			// is detached from previous node and started again
+			stream.cancel()
+			go client.abandon(stream)
+
+			// attempts are counted across reopened
+			// streams, which drop before the first row
+			if rows > 0 {
+				attempts = 0
+			}
+
+			stream, attempts, err = reopen(ctx, client, query, o, result, attempts, dropErr)
+			if err != nil {
+				if ctx.Err() != nil {
+					result.stop(ctx.Err())
 					return
 				}
 
-				if strings.Contains(string(val), "Query Completed") {
-					close(valuesC)
-					return
+				client.Logger().Error(
+					"reconnect push query",
+					slog.String("error", err.Error()),
+				)
+				result.finish(Failed, err)
+				return
+			}
+
+			result.attach(stream)
+
+			// Close, called during reconnect, has terminated
+			// previous query, so the new one is dropped as well
+			if result.closed.Load() {
+				client.abandon(stream)
+				result.stop(nil)
+				return
+			}
+
+			client.Logger().Info(
+				"push query is reconnected",
+				slog.String("host", stream.host),
+				slog.String("id", stream.header.QueryID),
+			)
+		}
+	}()
+
+	return result, nil
+}
+
+// queryStream - opened /query-stream connection
+type queryStream struct {
+	host   string
+	header dao.QueryStreamHeader
+	frames <-chan []byte
+	cancel context.CancelFunc // releases connection
+	finite bool               // pull and limited queries are finished by server
+}
+
+// openStream - starts query on one of cluster nodes
+// and awaits columns description
+func (c *Client) openStream(
+	ctx context.Context,
+	query string,
+	props network.Properties,
+	retry bool,
+) (queryStream, error) {
+
+	var (
+		stream = queryStream{
+			finite: isPullQuery(query) || isLimited(query),
+		}
+	)
+
+	c.Logger().Debug("ksql query", slog.Any("statement", util.Statement(query)))
+
+	// failed attempt must not keep reading its response
+	ctx, stream.cancel = context.WithCancel(ctx)
+
+	perform := func() (err error) {
+		stream.frames, stream.host, err = c.net.PerformQueryStream(
+			ctx,
+			query,
+			props,
+			network.Delimited{},
+		)
+		if err != nil {
+			return err
+		}
+
+		stream.header, err = readHeader(ctx, stream.frames)
+		return err
+	}
+
+	var (
+		err error
+	)
+
+	if retry {
+		err = c.net.Retry(ctx, perform)
+	} else {
+		err = perform()
+	}
+
+	if err != nil {
+		stream.cancel()
+	}
+
+	return stream, err
+}
+
+// abandon - best-effort termination of push query,
+// which connection was dropped, but node may be still alive
+func (c *Client) abandon(stream queryStream) {
+	ctx, cancel := context.WithTimeout(context.Background(), closeQueryTimeout)
+	defer cancel()
+
+	if err := c.net.CloseQuery(ctx, stream.host, stream.header.QueryID); err != nil {
+		c.Logger().Debug(
+			"abandoned query is not closed",
+			slog.String("id", stream.header.QueryID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// readStream - propagates rows of stream to result.
+// Returns count of propagated rows and error of broken
+// connection, if push query must be reconnected.
+// Stream, finished by server, is never reconnected
+func readStream[S any](
+	ctx context.Context,
+	logger *slog.Logger,
+	result *Result[S],
+	stream queryStream,
+) (int, error) {
+
+	var (
+		rows int
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			// caller context is done, so query
+			// must be terminated on server side as well
+			result.stop(ctx.Err())
+
+			if err := result.Close(); err != nil {
+				logger.Error(
+					"close query",
+					slog.String("id", result.ID()),
+					slog.String("error", err.Error()),
+				)
+			}
+			return rows, nil
+		case frame, ok := <-stream.frames:
+			if !ok {
+				// query is terminated with Close,
+				// so it must not be started again
+				if result.closed.Load() {
+					result.stop(nil)
+					return rows, nil
 				}
 
-				if iter == 0 {
-					str := val[1 : len(val)-1]
+				if ctx.Err() != nil {
+					result.stop(ctx.Err())
+					return rows, nil
+				}
 
-					if err = jsoniter.Unmarshal(str, &headers); err != nil {
-						slog.Debug("unmarshal resonse", "formatted", string(str))
-						close(valuesC)
-						return
-					}
+				// response is read till its end, push
+				// query is terminated on server side
+				result.completed.Store(true)
+				result.finish(Completed, nil)
+				return rows, nil
+			}
 
-					iter++
+			// every row is framed as json array,
+			// errors are json objects
+			if frame[0] != '[' {
+				if token := parseContinuationToken(frame); len(token) != 0 {
+					result.resume(token)
 					continue
 				}
 
-				var (
-					row dao.Row
-				)
+				// finite query lost part of rows, while
+				// push query is restored with reconnect
+				if brokenErr := parseBrokenStream(frame); brokenErr != nil {
+					if !stream.finite {
+						return rows, brokenErr
+					}
 
-				if err = jsoniter.Unmarshal(val[:len(val)-1], &row); err != nil {
-					close(valuesC)
-					return
-				}
-				value, err := netparse.ParseNetResponse[S](headers, row)
-				if err != nil {
-					close(valuesC)
-					slog.Error(
-						"parse net response",
-						slog.String("error", err.Error()),
-						slog.Any("headers", headers),
-						slog.Any("row", row),
+					logger.Error(
+						"query stream is broken",
+						slog.String("id", stream.header.QueryID),
+						slog.String("error", brokenErr.Error()),
 					)
-					return
+					result.finish(Failed, brokenErr)
+					return rows, nil
+				}
+
+				streamErr := parseStreamError(frame)
+				if streamErr == nil {
+					// other control frames, e.g.
+					// consistency tokens, are skipped
+					continue
 				}
 
-				valuesC <- value
+				logger.Error(
+					"query stream error",
+					slog.String("error", streamErr.Error()),
+				)
+				result.finish(Failed, streamErr)
+				return rows, nil
+			}
+
+			var (
+				columns []any
+			)
+
+			if err := jsoniter.Unmarshal(frame, &columns); err != nil {
+				result.finish(Failed, errors.Join(libErrors.ErrUnserializableResponse, err))
+				return rows, nil
+			}
+
+			value, err := netparse.ParseStreamResponse[S](stream.header, columns)
+			if err != nil {
+				logger.Error(
+					"parse net response",
+					slog.String("id", stream.header.QueryID),
+					slog.String("error", err.Error()),
+					slog.Any("columns", stream.header.ColumnNames),
+				)
+				result.finish(Failed, err)
+				return rows, nil
 			}
+
+			if !result.push(ctx, value) {
+				logger.Warn(
+					"select values buffer overflow",
+					slog.String("id", stream.header.QueryID),
+				)
+				result.finish(Failed, libErrors.ErrBufferOverflow)
+				return rows, nil
+			}
+
+			rows++
 		}
-	}()
+	}
+}
+
+// readHeader - awaits first frame of query stream.
+// It's either columns description or query error
+func readHeader(
+	ctx context.Context,
+	response <-chan []byte,
+) (dao.QueryStreamHeader, error) {
+
+	var (
+		header dao.QueryStreamHeader
+	)
+
+	select {
+	case <-ctx.Done():
+		return header, ctx.Err()
+	case frame, ok := <-response:
+		if !ok {
+			return header, libErrors.ErrMalformedResponse
+		}
+
+		if streamErr := parseStreamError(frame); streamErr != nil {
+			return header, streamErr
+		}
+
+		if brokenErr := parseBrokenStream(frame); brokenErr != nil {
+			return header, brokenErr
+		}
+
+		if err := jsoniter.Unmarshal(frame, &header); err != nil {
+			return header, errors.Join(libErrors.ErrUnserializableResponse, err)
+		}
+
+		return header, nil
+	}
+}
+
+// parseStreamError - checks if frame is ksql error message
+func parseStreamError(frame []byte) *libErrors.KsqlError {
+	var (
+		streamErr dao.ErrorMessage
+	)
+
+	if err := jsoniter.Unmarshal(frame, &streamErr); err != nil {
+		return nil
+	}
+
+	if streamErr.ErrorCode == 0 {
+		return nil
+	}
+
+	return streamErr.Err(0)
+}
+
+// parseBrokenStream - checks if frame reports,
+// that response was not read till its end
+func parseBrokenStream(frame []byte) error {
+	var (
+		msg dao.ErrorMessage
+	)
+
+	if err := jsoniter.Unmarshal(frame, &msg); err != nil || !msg.Broken() {
+		return nil
+	}
+
+	return msg.BrokenErr()
+}
+
+// parseContinuationToken - checks if frame is
+// continuation token of scalable push query
+func parseContinuationToken(frame []byte) string {
+	var (
+		token dao.ContinuationToken
+	)
+
+	if err := jsoniter.Unmarshal(frame, &token); err != nil {
+		return ""
+	}
 
-	return valuesC, nil
+	return token.ContinuationToken
 }
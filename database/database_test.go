@@ -0,0 +1,299 @@
+package database
+
+import (
+	"context"
+	"github.com/gulfstream-h/ksql/consts"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/gulfstream-h/ksql/internal/kernel/network"
+	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dao"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// streamServer - fake ksqlDB node, which
+// serves handler over plain http and h2c
+func streamServer(t *testing.T, handler http.Handler) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Config.Protocols = new(http.Protocols)
+	server.Config.Protocols.SetHTTP1(true)
+	server.Config.Protocols.SetUnencryptedHTTP2(true)
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// streamClient - client of fake ksqlDB node
+func streamClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	return hostClient(t, streamServer(t, handler).URL)
+}
+
+// hostClient - client of single ksqlDB node
+func hostClient(t *testing.T, host string) *Client {
+	t.Helper()
+
+	client := NewClient(network.New(network.Settings{
+		Hosts:   []string{host},
+		Timeout: time.Second,
+	}), false, nil, nil)
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+func Test_ReadStreamEnd(t *testing.T) {
+	testcases := []struct {
+		name       string
+		finite     bool
+		closed     bool
+		frames     []string
+		wantRows   int
+		wantDrop   bool
+		wantReason Reason
+	}{
+		{
+			name:       "Finite query is completed",
+			finite:     true,
+			frames:     []string{`[1]`},
+			wantRows:   1,
+			wantReason: Completed,
+		},
+		{
+			name:       "Closed push query is not reconnected",
+			closed:     true,
+			wantReason: Closed,
+		},
+		{
+			name:       "Push query, finished by server, is completed",
+			frames:     []string{`[1]`, `[2]`},
+			wantRows:   2,
+			wantReason: Completed,
+		},
+		{
+			name:       "Broken push query is reconnected",
+			frames:     []string{`[1]`, `{"@type":"broken_stream","message":"unexpected EOF"}`},
+			wantRows:   1,
+			wantDrop:   true,
+			wantReason: Running,
+		},
+		{
+			name:       "Broken finite query is failed",
+			finite:     true,
+			frames:     []string{`{"@type":"broken_stream","message":"unexpected EOF"}`},
+			wantReason: Failed,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			frames := make(chan []byte, len(tc.frames))
+			for _, frame := range tc.frames {
+				frames <- []byte(frame)
+			}
+			close(frames)
+
+			result := &Result[struct {
+				ID int `ksql:"ID"`
+			}]{
+				values: make(chan struct {
+					ID int `ksql:"ID"`
+				}, len(tc.frames)),
+				cancel: func() {},
+			}
+			result.closed.Store(tc.closed)
+
+			rows, dropErr := readStream(
+				context.Background(),
+				slog.Default(),
+				result,
+				queryStream{
+					header: dao.QueryStreamHeader{
+						ColumnNames: []string{"ID"},
+						ColumnTypes: []string{"INTEGER"},
+					},
+					frames: frames,
+					finite: tc.finite,
+				},
+			)
+			assert.Equal(t, tc.wantRows, rows)
+			assert.Equal(t, tc.wantReason, result.Reason())
+
+			if tc.wantDrop {
+				assert.ErrorIs(t, dropErr, libErrors.ErrBrokenStream)
+			} else {
+				assert.NoError(t, dropErr)
+			}
+
+			if tc.wantReason == Failed {
+				assert.ErrorIs(t, result.Err(), libErrors.ErrBrokenStream)
+			} else {
+				assert.NoError(t, result.Err())
+			}
+		})
+	}
+}
+
+func Test_SelectBrokenStream(t *testing.T) {
+	const (
+		header = `{"queryId":"query_1","columnNames":["ID","NAME"],"columnTypes":["INTEGER","STRING"]}` + "\n"
+		row    = `[1,"first"]` + "\n"
+	)
+
+	testcases := []struct {
+		name       string
+		write      func(w http.ResponseWriter)
+		wantRows   int
+		wantReason Reason
+		wantErr    string
+	}{
+		{
+			name: "Complete response",
+			write: func(w http.ResponseWriter) {
+				w.Write([]byte(header + row + `[2,"second"]` + "\n"))
+			},
+			wantRows:   2,
+			wantReason: Completed,
+		},
+		{
+			name: "Oversize frame",
+			write: func(w http.ResponseWriter) {
+				w.Write([]byte(header + row))
+				w.Write([]byte(`[2,"` + strings.Repeat("x", 5*1024*1024) + `"]` + "\n"))
+			},
+			wantRows:   1,
+			wantReason: Failed,
+			wantErr:    "token too long",
+		},
+		{
+			name: "Truncated body",
+			write: func(w http.ResponseWriter) {
+				w.Write([]byte(header + row + `[2,"sec`))
+				w.(http.Flusher).Flush()
+				panic(http.ErrAbortHandler)
+			},
+			wantRows:   1,
+			wantReason: Failed,
+			wantErr:    "stream error",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := streamClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				tc.write(w)
+			}))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			result, err := Select[struct {
+				ID   int    `ksql:"ID"`
+				Name string `ksql:"NAME"`
+			}](ctx, "SELECT ID, NAME FROM USERS WHERE ID > 0;", WithClient(client))
+			assert.NoError(t, err)
+
+			rows := 0
+			for range result.Values() {
+				rows++
+			}
+
+			assert.Equal(t, tc.wantRows, rows)
+			assert.Equal(t, tc.wantReason, result.Reason())
+
+			if len(tc.wantErr) == 0 {
+				assert.NoError(t, result.Err())
+				return
+			}
+
+			assert.ErrorIs(t, result.Err(), libErrors.ErrBrokenStream)
+			assert.ErrorContains(t, result.Err(), tc.wantErr)
+		})
+	}
+}
+
+func Test_SelectReconnectCount(t *testing.T) {
+	const (
+		header = `{"queryId":"query_1","columnNames":["ID"],"columnTypes":["INTEGER"]}` + "\n"
+	)
+
+	testcases := []struct {
+		name         string
+		broken       bool
+		opts         []Option
+		wantRequests int64
+		wantReason   Reason
+	}{
+		{
+			name:         "Push query, finished by server, is not reopened",
+			wantRequests: 1,
+			wantReason:   Completed,
+		},
+		{
+			name:         "Broken push query is reopened once",
+			broken:       true,
+			wantRequests: 2,
+			wantReason:   Failed,
+		},
+		{
+			name:         "Broken push query is reopened according to policy",
+			broken:       true,
+			opts:         []Option{WithReconnectPolicy(ReconnectPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})},
+			wantRequests: 4,
+			wantReason:   Failed,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				requests atomic.Int64
+			)
+
+			client := streamClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				if r.URL.Path == consts.CloseQueryRoute {
+					return
+				}
+
+				requests.Add(1)
+
+				// every stream ends before the first row
+				w.Write([]byte(header))
+				w.(http.Flusher).Flush()
+
+				if tc.broken {
+					panic(http.ErrAbortHandler)
+				}
+			}))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			result, err := Select[struct {
+				ID int `ksql:"ID"`
+			}](ctx, "SELECT ID FROM NUMBERS EMIT CHANGES;", append(tc.opts, WithClient(client))...)
+			assert.NoError(t, err)
+
+			for range result.Values() {
+			}
+
+			assert.Equal(t, tc.wantRequests, requests.Load())
+			assert.Equal(t, tc.wantReason, result.Reason())
+
+			if tc.wantReason == Failed {
+				assert.ErrorIs(t, result.Err(), libErrors.ErrBrokenStream)
+			}
+		})
+	}
+}
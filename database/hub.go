@@ -0,0 +1,254 @@
+package database
+
+import (
+	"context"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Hub - shares single push query between many in-process
+// consumers. Upstream query is started with the first
+// attached consumer and terminated, when the last one leaves
+type Hub[S any] struct {
+	query string
+	opts  []Option
+
+	mu        sync.Mutex
+	upstream  *Result[S]
+	consumers map[*consumer[S]]struct{}
+}
+
+// consumer - attached to hub reader with own buffer and filter
+type consumer[S any] struct {
+	ctx    context.Context
+	filter func(S) bool
+	result *Result[S]
+
+	// mu guards values channel, which is
+	// filled outside of hub lock, from closing
+	mu   sync.Mutex
+	done bool
+}
+
+// NewHub - creates hub for push query. Options are applied
+// to upstream query, e.g. client, properties or reconnect policy
+func NewHub[S any](query string, opts ...Option) *Hub[S] {
+	return &Hub[S]{
+		query:     query,
+		opts:      opts,
+		consumers: make(map[*consumer[S]]struct{}),
+	}
+}
+
+// Attach - subscribes consumer to hub. Only rows, that satisfy
+// filter (all rows, if it's nil), are propagated to returned
+// handle. Buffer of consumer is set with WithBuffer option, so
+// slow consumer with blocking policy delays all others.
+// Consumer is detached with Close or when context is done
+func (h *Hub[S]) Attach(
+	ctx context.Context,
+	filter func(S) bool,
+	opts ...Option,
+) (*Result[S], error) {
+
+	var (
+		o       = newOptions(opts...)
+		started *Result[S]
+		err     error
+	)
+
+	h.mu.Lock()
+
+	for h.upstream == nil {
+		if started != nil {
+			h.upstream, started = started, nil
+			go h.dispatch(h.upstream)
+			break
+		}
+
+		h.mu.Unlock()
+
+		// upstream is started without lock, so other consumers
+		// are not blocked by network. It outlives consumer,
+		// which started it
+		started, err = Select[S](context.Background(), h.query, h.opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		h.mu.Lock()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	c := &consumer[S]{
+		ctx:    ctx,
+		filter: filter,
+		result: &Result[S]{
+			values:   make(chan S, o.bufferSize()),
+			cancel:   cancel,
+			overflow: o.overflow,
+		},
+	}
+	h.consumers[c] = struct{}{}
+
+	h.mu.Unlock()
+
+	// concurrent consumer has already started upstream
+	if started != nil {
+		started.Close()
+	}
+
+	context.AfterFunc(ctx, func() {
+		h.detach(c, ctx.Err())
+	})
+
+	return c.result, nil
+}
+
+// Close - detaches all consumers and terminates upstream query
+func (h *Hub[S]) Close() error {
+	h.mu.Lock()
+
+	for c := range h.consumers {
+		c.result.closed.Store(true)
+		c.result.finish(Closed, nil)
+		h.remove(c)
+	}
+
+	upstream := h.upstream
+	h.upstream = nil
+
+	h.mu.Unlock()
+
+	if upstream == nil {
+		return nil
+	}
+
+	return upstream.Close()
+}
+
+// Consumers - returns count of attached consumers
+func (h *Hub[S]) Consumers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.consumers)
+}
+
+// dispatch - propagates rows of upstream query to consumers.
+// Rows are pushed outside of hub lock, so slow consumer
+// doesn't block attaching and detaching of others
+func (h *Hub[S]) dispatch(upstream *Result[S]) {
+	for value := range upstream.Values() {
+		h.mu.Lock()
+
+		// rows of replaced upstream are not delivered
+		if h.upstream != upstream {
+			h.mu.Unlock()
+			continue
+		}
+
+		consumers := slices.Collect(maps.Keys(h.consumers))
+
+		h.mu.Unlock()
+
+		for _, c := range consumers {
+			if c.filter != nil && !c.filter(value) {
+				continue
+			}
+
+			if !c.push(value) {
+				h.overflow(c)
+			}
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.upstream != upstream {
+		return
+	}
+
+	// upstream is finished by server or broken,
+	// so consumers share its reason and error
+	for c := range h.consumers {
+		c.result.finish(upstream.Reason(), upstream.Err())
+		h.remove(c)
+	}
+
+	h.upstream = nil
+}
+
+// detach - removes consumer, whose context is done, and
+// terminates upstream query, if it was the last consumer
+func (h *Hub[S]) detach(c *consumer[S], err error) {
+	h.mu.Lock()
+
+	if _, ok := h.consumers[c]; !ok {
+		h.mu.Unlock()
+		return
+	}
+
+	c.result.stop(err)
+	h.remove(c)
+
+	var (
+		upstream *Result[S]
+	)
+
+	if len(h.consumers) == 0 {
+		upstream = h.upstream
+		h.upstream = nil
+	}
+
+	h.mu.Unlock()
+
+	if upstream != nil {
+		upstream.Close()
+	}
+}
+
+// overflow - fails consumer, whose buffer is full
+func (h *Hub[S]) overflow(c *consumer[S]) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.consumers[c]; !ok {
+		return
+	}
+
+	c.result.finish(Failed, libErrors.ErrBufferOverflow)
+	h.remove(c)
+}
+
+// remove - closes channel of finished consumer.
+// Must be called with locked mutex
+func (h *Hub[S]) remove(c *consumer[S]) {
+	delete(h.consumers, c)
+
+	// blocked push is released before channel is closed
+	c.result.cancel()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.done = true
+	close(c.result.values)
+}
+
+// push - passes row to consumer, that is still attached.
+// Returns false, if buffer of consumer is overflowed
+func (c *consumer[S]) push(value S) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.done {
+		return true
+	}
+
+	return c.result.push(c.ctx, value)
+}
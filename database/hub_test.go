@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// runningHub - hub with started upstream, fed by returned channel
+func runningHub(t *testing.T) (*Hub[int], chan int, *Result[int]) {
+	t.Helper()
+
+	var (
+		hub    = NewHub[int]("SELECT * FROM NUMBERS EMIT CHANGES;")
+		values = make(chan int)
+	)
+
+	upstream := &Result[int]{
+		values: values,
+		cancel: func() {},
+	}
+
+	hub.upstream = upstream
+	go hub.dispatch(upstream)
+
+	return hub, values, upstream
+}
+
+// collect - reads consumer till its channel is closed
+func collect(t *testing.T, result *Result[int]) []int {
+	t.Helper()
+
+	var (
+		values  []int
+		timeout = time.After(time.Second)
+	)
+
+	for {
+		select {
+		case value, ok := <-result.Values():
+			if !ok {
+				return values
+			}
+			values = append(values, value)
+		case <-timeout:
+			t.Fatal("consumer channel is not closed")
+		}
+	}
+}
+
+func Test_HubFanOut(t *testing.T) {
+	testcases := []struct {
+		name     string
+		filter   func(int) bool
+		expected []int
+	}{
+		{
+			name:     "All rows",
+			expected: []int{1, 2, 3, 4},
+		},
+		{
+			name:     "Filtered rows",
+			filter:   func(v int) bool { return v%2 == 0 },
+			expected: []int{2, 4},
+		},
+	}
+
+	hub, values, upstream := runningHub(t)
+
+	results := make([]*Result[int], len(testcases))
+	for idx, tc := range testcases {
+		result, err := hub.Attach(context.Background(), tc.filter, WithBuffer(10, OverflowBlock))
+		assert.NoError(t, err)
+		results[idx] = result
+	}
+	assert.Equal(t, len(testcases), hub.Consumers())
+
+	for value := 1; value <= 4; value++ {
+		values <- value
+	}
+
+	upstream.finish(Completed, nil)
+	close(values)
+
+	for idx, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, collect(t, results[idx]))
+			assert.Equal(t, Completed, results[idx].Reason())
+		})
+	}
+
+	assert.Zero(t, hub.Consumers())
+}
+
+func Test_HubDetach(t *testing.T) {
+	hub, values, upstream := runningHub(t)
+
+	first, err := hub.Attach(context.Background(), nil)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	second, err := hub.Attach(ctx, nil)
+	assert.NoError(t, err)
+
+	cancel()
+	assert.Empty(t, collect(t, second))
+	assert.Equal(t, Cancelled, second.Reason())
+	assert.ErrorIs(t, second.Err(), context.Canceled)
+	assert.False(t, upstream.closed.Load())
+
+	// remaining consumer still receives rows
+	values <- 1
+	assert.Equal(t, 1, <-first.Values())
+
+	// last consumer terminates upstream
+	assert.NoError(t, first.Close())
+	assert.Empty(t, collect(t, first))
+	assert.Equal(t, Closed, first.Reason())
+	assert.True(t, upstream.closed.Load())
+	assert.Zero(t, hub.Consumers())
+}
+
+func Test_HubClose(t *testing.T) {
+	hub, _, upstream := runningHub(t)
+
+	results := make([]*Result[int], 3)
+	for idx := range results {
+		result, err := hub.Attach(context.Background(), nil)
+		assert.NoError(t, err)
+		results[idx] = result
+	}
+
+	assert.NoError(t, hub.Close())
+	assert.True(t, upstream.closed.Load())
+	assert.Zero(t, hub.Consumers())
+
+	for _, result := range results {
+		assert.Empty(t, collect(t, result))
+		assert.Equal(t, Closed, result.Reason())
+		assert.NoError(t, result.Err())
+	}
+}
+
+func Test_HubOverflow(t *testing.T) {
+	hub, values, _ := runningHub(t)
+
+	slow, err := hub.Attach(context.Background(), nil, WithBuffer(1, OverflowFail))
+	assert.NoError(t, err)
+
+	fast, err := hub.Attach(context.Background(), nil, WithBuffer(10, OverflowBlock))
+	assert.NoError(t, err)
+
+	// third row is received, when second one is dispatched
+	values <- 1
+	values <- 2
+	values <- 3
+
+	assert.Equal(t, []int{1}, collect(t, slow))
+	assert.Equal(t, Failed, slow.Reason())
+	assert.ErrorIs(t, slow.Err(), libErrors.ErrBufferOverflow)
+
+	assert.Equal(t, 1, <-fast.Values())
+	assert.Equal(t, 2, <-fast.Values())
+	assert.Equal(t, 3, <-fast.Values())
+	assert.Equal(t, 1, hub.Consumers())
+}
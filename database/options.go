@@ -0,0 +1,239 @@
+package database
+
+import (
+	"github.com/gulfstream-h/ksql/internal/kernel/network"
+	"github.com/gulfstream-h/ksql/internal/util"
+	"github.com/gulfstream-h/ksql/ksql"
+)
+
+type (
+	// Option - configures single call of
+	// database or ORM packages function
+	Option func(opts *options)
+
+	// options - accumulated call settings
+	options struct {
+		client     *Client
+		retry      bool
+		properties network.Properties
+
+		// reconnect - backoff of dropped push query,
+		// nil keeps single attempt with client retry policy
+		reconnect   *ReconnectPolicy
+		onReconnect func(ReconnectEvent)
+
+		// capacity and overflow - buffering of select values
+		capacity int
+		overflow Overflow
+
+		// filters and limit - narrowing of
+		// select queries built by ORM packages
+		filters []ksql.Conditional
+		limit   int
+
+		// args - values of statement placeholders,
+		// bound is set even if no values are passed
+		args  []any
+		bound bool
+	}
+)
+
+const (
+	// AutoOffsetReset - streams property, that
+	// defines where push query starts reading topic
+	AutoOffsetReset = "auto.offset.reset"
+)
+
+// WithClient - performs call with provided client
+// instead of default one
+func WithClient(client *Client) Option {
+	return func(opts *options) {
+		opts.client = client
+	}
+}
+
+// WithRetry - repeats statement on temporary failures
+// according to client retry policy. Reads are retried
+// by default, other statements must opt in, since they
+// can be applied twice if response was lost
+func WithRetry() Option {
+	return func(opts *options) {
+		opts.retry = true
+	}
+}
+
+// WithProperty - sets ksqlDB streams property for the call,
+// e.g. auto.offset.reset or ksql.query.pull.table.scan.enabled.
+// It overrides client default with the same key
+func WithProperty(key string, value any) Option {
+	return func(opts *options) {
+		if opts.properties.Streams == nil {
+			opts.properties.Streams = make(map[string]any)
+		}
+		opts.properties.Streams[key] = value
+	}
+}
+
+// WithProperties - sets several streams properties for the call
+func WithProperties(properties map[string]any) Option {
+	return func(opts *options) {
+		for key, value := range properties {
+			WithProperty(key, value)(opts)
+		}
+	}
+}
+
+// WithSessionVariable - sets variable, that is
+// substituted into ${name} placeholders of statement
+func WithSessionVariable(name string, value any) Option {
+	return func(opts *options) {
+		if opts.properties.Variables == nil {
+			opts.properties.Variables = make(map[string]any)
+		}
+		opts.properties.Variables[name] = value
+	}
+}
+
+// WithSessionVariables - sets several session variables for the call
+func WithSessionVariables(variables map[string]any) Option {
+	return func(opts *options) {
+		for name, value := range variables {
+			WithSessionVariable(name, value)(opts)
+		}
+	}
+}
+
+// WithCommandSequence - makes server await execution of command
+// with provided sequence number before running statement.
+// By default, highest number received by client is used.
+// It applies to statements, sent to /ksql endpoint, e.g.
+// DESCRIBE or LIST. Select queries over /query-stream don't
+// carry it, so they aren't guaranteed to see previous commands
+func WithCommandSequence(sequence int64) Option {
+	return func(opts *options) {
+		opts.properties.CommandSequence = sequence
+	}
+}
+
+// FromEarliest - reads relation from the beginning of
+// topic instead of new records only. It allows
+// SelectOnce to receive historical data
+func FromEarliest() Option {
+	return WithProperty(AutoOffsetReset, "earliest")
+}
+
+// WithContinuationTokens - asks server to emit continuation
+// tokens, so Subscribe resumes push query without losing
+// or repeating rows. Supported by scalable push queries only
+func WithContinuationTokens() Option {
+	return WithProperty(ContinuationTokensEnabled, true)
+}
+
+// WithReconnectPolicy - sets backoff, which is used
+// by Subscribe to restore dropped push query
+func WithReconnectPolicy(policy ReconnectPolicy) Option {
+	return func(opts *options) {
+		opts.reconnect = &policy
+	}
+}
+
+// OnReconnect - reports every attempt of
+// restoring dropped push query to handler
+func OnReconnect(handler func(ReconnectEvent)) Option {
+	return func(opts *options) {
+		opts.onReconnect = handler
+	}
+}
+
+// WithBuffer - sets capacity of select values channel
+// and policy, which is applied when it's full. Policies,
+// that don't block, buffer at least one row
+func WithBuffer(capacity int, overflow Overflow) Option {
+	return func(opts *options) {
+		opts.capacity = capacity
+		opts.overflow = overflow
+	}
+}
+
+// WithFilter - narrows select query of stream or table
+// with WHERE conditions, which are applied on server side
+func WithFilter(conditions ...ksql.Conditional) Option {
+	return func(opts *options) {
+		opts.filters = append(opts.filters, conditions...)
+	}
+}
+
+// WithLimit - completes select query of stream
+// or table after n rows are received. Non-positive
+// n leaves push query unbounded
+func WithLimit(n int) Option {
+	return func(opts *options) {
+		opts.limit = n
+	}
+}
+
+// WithArgs - binds values to ? placeholders of statement.
+// Every value is serialized into escaped literal, so
+// application data is never concatenated into statement
+func WithArgs(args ...any) Option {
+	return func(opts *options) {
+		opts.args = append(opts.args, args...)
+		opts.bound = true
+	}
+}
+
+// bind - substitutes placeholders of statement with arguments
+func (o options) bind(query string) (string, error) {
+	if !o.bound {
+		return query, nil
+	}
+
+	return util.Bind(query, o.args...)
+}
+
+// bufferSize - returns capacity of values channel
+func (o options) bufferSize() int {
+	capacity := max(o.capacity, 0)
+	if o.overflow != OverflowBlock {
+		capacity = max(capacity, 1)
+	}
+
+	return capacity
+}
+
+// newOptions - applies all passed options
+func newOptions(opts ...Option) options {
+	var (
+		o options
+	)
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	return o
+}
+
+// ClientOf - returns client chosen with WithClient option
+// or default one, if option is not passed
+func ClientOf(opts ...Option) *Client {
+	o := newOptions(opts...)
+	if o.client != nil {
+		return o.client
+	}
+
+	return Default()
+}
+
+// FiltersOf - returns conditions passed with WithFilter option
+func FiltersOf(opts ...Option) []ksql.Conditional {
+	return newOptions(opts...).filters
+}
+
+// LimitOf - returns rows limit passed with
+// WithLimit option or zero, if it's not passed
+func LimitOf(opts ...Option) int {
+	return newOptions(opts...).limit
+}
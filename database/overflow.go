@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+)
+
+type (
+	Overflow int // Policies of full values channel
+)
+
+const (
+	OverflowBlock      = Overflow(iota) // reader waits for consumer, server is slowed down
+	OverflowDropOldest                  // the oldest buffered row is discarded
+	OverflowDropNewest                  // received row is discarded
+	OverflowFail                        // query is failed with ErrBufferOverflow
+)
+
+func (o Overflow) String() string {
+	switch o {
+	case OverflowBlock:
+		return "BLOCK"
+	case OverflowDropOldest:
+		return "DROP_OLDEST"
+	case OverflowDropNewest:
+		return "DROP_NEWEST"
+	case OverflowFail:
+		return "FAIL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// push - passes row to consumer according to overflow
+// policy. Returns false, if row cannot be delivered
+// and query must be failed
+func (r *Result[S]) push(ctx context.Context, value S) bool {
+	switch r.overflow {
+	case OverflowDropNewest:
+		select {
+		case r.values <- value:
+		default:
+			r.dropped.Add(1)
+		}
+		return true
+	case OverflowDropOldest:
+		// reader is the only sender, so
+		// freed slot can't be taken by other row
+		for {
+			select {
+			case r.values <- value:
+				return true
+			default:
+			}
+
+			select {
+			case <-r.values:
+				r.dropped.Add(1)
+			default:
+			}
+		}
+	case OverflowFail:
+		select {
+		case r.values <- value:
+			return true
+		default:
+			return false
+		}
+	default:
+		select {
+		case <-ctx.Done():
+		case r.values <- value:
+		}
+		return true
+	}
+}
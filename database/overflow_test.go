@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dao"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func Test_ResultPush(t *testing.T) {
+	testcases := []struct {
+		name        string
+		overflow    Overflow
+		pushed      []int
+		wantOk      []bool
+		wantValues  []int
+		wantDropped uint64
+	}{
+		{
+			name:        "Drop newest",
+			overflow:    OverflowDropNewest,
+			pushed:      []int{1, 2, 3},
+			wantOk:      []bool{true, true, true},
+			wantValues:  []int{1, 2},
+			wantDropped: 1,
+		},
+		{
+			name:        "Drop oldest",
+			overflow:    OverflowDropOldest,
+			pushed:      []int{1, 2, 3, 4},
+			wantOk:      []bool{true, true, true, true},
+			wantValues:  []int{3, 4},
+			wantDropped: 2,
+		},
+		{
+			name:       "Fail",
+			overflow:   OverflowFail,
+			pushed:     []int{1, 2, 3},
+			wantOk:     []bool{true, true, false},
+			wantValues: []int{1, 2},
+		},
+		{
+			name:       "Block is released by context",
+			overflow:   OverflowBlock,
+			pushed:     []int{1, 2, 3},
+			wantOk:     []bool{true, true, true},
+			wantValues: []int{1, 2},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			result := &Result[int]{
+				values:   make(chan int, 2),
+				overflow: tc.overflow,
+			}
+
+			for idx, value := range tc.pushed {
+				assert.Equal(t, tc.wantOk[idx], result.push(ctx, value))
+			}
+			close(result.values)
+
+			var (
+				values []int
+			)
+
+			for value := range result.Values() {
+				values = append(values, value)
+			}
+
+			assert.Equal(t, tc.wantValues, values)
+			assert.Equal(t, tc.wantDropped, result.Dropped())
+		})
+	}
+}
+
+func Test_ReadStreamOverflow(t *testing.T) {
+	type number struct {
+		ID int `ksql:"ID"`
+	}
+
+	frames := make(chan []byte, 2)
+	frames <- []byte(`[1]`)
+	frames <- []byte(`[2]`)
+
+	result := &Result[number]{
+		values:   make(chan number, 1),
+		cancel:   func() {},
+		overflow: OverflowFail,
+	}
+
+	rows, dropErr := readStream(
+		context.Background(),
+		slog.Default(),
+		result,
+		queryStream{
+			header: dao.QueryStreamHeader{
+				QueryID:     "query_1",
+				ColumnNames: []string{"ID"},
+				ColumnTypes: []string{"INTEGER"},
+			},
+			frames: frames,
+		},
+	)
+	assert.Equal(t, 1, rows)
+	assert.NoError(t, dropErr)
+	assert.Equal(t, Failed, result.Reason())
+	assert.ErrorIs(t, result.Err(), libErrors.ErrBufferOverflow)
+	assert.Equal(t, 1, (<-result.Values()).ID)
+}
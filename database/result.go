@@ -0,0 +1,219 @@
+package database
+
+import (
+	"context"
+	"github.com/gulfstream-h/ksql/internal/kernel/network"
+	"iter"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// closeQueryTimeout - limits /close-query request, which
+	// is performed apart from already cancelled select context
+	closeQueryTimeout = 10 * time.Second
+)
+
+type (
+	Reason int // Reasons of select query finishing
+)
+
+const (
+	Running   = Reason(iota) // query is still streaming rows
+	Completed                // server finished query: pull query or limit reached
+	Closed                   // query was terminated with Result.Close
+	Cancelled                // select context is done
+	Failed                   // query is broken, error is returned by Result.Err
+)
+
+func (r Reason) String() string {
+	switch r {
+	case Running:
+		return "RUNNING"
+	case Completed:
+		return "COMPLETED"
+	case Closed:
+		return "CLOSED"
+	case Cancelled:
+		return "CANCELLED"
+	case Failed:
+		return "FAILED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Result - handle of running select query.
+// It carries server-side query id, so push
+// query can be explicitly terminated on ksqlDB
+type Result[S any] struct {
+	net    *network.Transport
+	values chan S
+	cancel context.CancelFunc
+
+	overflow Overflow
+	dropped  atomic.Uint64
+
+	// completed is set when server closes
+	// the stream by itself (pull query or limit reached)
+	completed atomic.Bool
+	// closed is set when caller terminates query
+	closed atomic.Bool
+
+	closeOnce sync.Once
+	closeErr  error
+
+	// host and id are replaced, when
+	// push query is reconnected to other node
+	mu     sync.Mutex
+	host   string
+	id     string
+	token  string
+	reason Reason
+	err    error
+}
+
+// ID - returns ksqlDB query identifier
+func (r *Result[S]) ID() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.id
+}
+
+// attach - binds result to running query
+func (r *Result[S]) attach(stream queryStream) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.host = stream.host
+	r.id = stream.header.QueryID
+}
+
+// ContinuationToken - returns position of last
+// received row of scalable push query, if server emits
+// continuation tokens. Subscribe resumes query from it
+func (r *Result[S]) ContinuationToken() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.token
+}
+
+// resume - remembers last continuation token
+func (r *Result[S]) resume(token string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.token = token
+}
+
+// Values - returns channel with received rows.
+// Channel is closed when query is completed,
+// failed or terminated. Reason and error are
+// available after channel is closed
+func (r *Result[S]) Values() <-chan S {
+	return r.values
+}
+
+// Dropped - returns count of rows, that were
+// discarded by overflow policy, since consumer was slow
+func (r *Result[S]) Dropped() uint64 {
+	return r.dropped.Load()
+}
+
+// Err - returns error, that broke query:
+// server error message, unparsable row or lost
+// connection. It's nil while query is running
+// and when query is completed or closed
+func (r *Result[S]) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.err
+}
+
+// Reason - returns why query is finished
+// or Running, if it's still streaming rows
+func (r *Result[S]) Reason() Reason {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reason
+}
+
+// All - iterates over received rows. When query
+// is broken, last iteration yields its error
+func (r *Result[S]) All() iter.Seq2[S, error] {
+	return func(yield func(S, error) bool) {
+		for value := range r.values {
+			if !yield(value, nil) {
+				return
+			}
+		}
+
+		if err := r.Err(); err != nil {
+			var (
+				zero S
+			)
+			yield(zero, err)
+		}
+	}
+}
+
+// finish - records why query is finished.
+// It must be called before values channel is closed
+func (r *Result[S]) finish(reason Reason, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.reason != Running {
+		return
+	}
+
+	r.reason = reason
+	r.err = err
+}
+
+// stop - records termination of query. Explicit Close
+// is not an error, while cancellation of select
+// context is reported with context error
+func (r *Result[S]) stop(err error) {
+	if r.closed.Load() {
+		r.finish(Closed, nil)
+		return
+	}
+
+	r.finish(Cancelled, err)
+}
+
+// Close - terminates query on ksqlDB server via /close-query
+// and drops connection. Returns error if server
+// did not confirm termination. Queries, that were
+// already completed by server, are only disconnected
+func (r *Result[S]) Close() error {
+	r.closed.Store(true)
+
+	r.closeOnce.Do(func() {
+		// connection is dropped before /close-query,
+		// so reader doesn't take server-side
+		// termination for lost connection
+		r.cancel()
+
+		r.mu.Lock()
+		host, id := r.host, r.id
+		r.mu.Unlock()
+
+		if r.completed.Load() || len(id) == 0 {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), closeQueryTimeout)
+		defer cancel()
+
+		r.closeErr = r.net.CloseQuery(ctx, host, id)
+	})
+
+	return r.closeErr
+}
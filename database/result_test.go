@@ -0,0 +1,95 @@
+package database
+
+import (
+	"github.com/gulfstream-h/ksql/consts"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dao"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func Test_ResultClose(t *testing.T) {
+	testcases := []struct {
+		name         string
+		id           string
+		completed    bool
+		status       int
+		body         string
+		wantRequests int64
+		wantErr      error
+	}{
+		{
+			name:         "Running query is terminated",
+			id:           "query_1",
+			status:       http.StatusOK,
+			wantRequests: 1,
+		},
+		{
+			name:      "Completed query is only disconnected",
+			id:        "query_1",
+			completed: true,
+			status:    http.StatusOK,
+		},
+		{
+			name:   "Query without id is only disconnected",
+			status: http.StatusOK,
+		},
+		{
+			name:         "Termination is not confirmed",
+			id:           "query_1",
+			status:       http.StatusBadRequest,
+			body:         `{"@type":"generic_error","error_code":40400,"message":"No query with id query_1"}`,
+			wantRequests: 1,
+			wantErr:      libErrors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				requests  atomic.Int64
+				cancelled atomic.Int64
+			)
+
+			server := streamServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, consts.CloseQueryRoute, r.URL.Path)
+				assert.Equal(t, consts.HeaderJSON, r.Header.Get(consts.ContentType))
+				assert.JSONEq(t, `{"queryId":"`+tc.id+`"}`, string(body))
+
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+
+			result := &Result[struct{}]{
+				net:    hostClient(t, server.URL).net,
+				cancel: func() { cancelled.Add(1) },
+			}
+			result.attach(queryStream{
+				host:   server.URL,
+				header: dao.QueryStreamHeader{QueryID: tc.id},
+			})
+			result.completed.Store(tc.completed)
+
+			err := result.Close()
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+
+			// repeated Close neither terminates
+			// query again nor changes its error
+			assert.Equal(t, err, result.Close())
+
+			assert.Equal(t, tc.wantRequests, requests.Load())
+			assert.Equal(t, int64(1), cancelled.Load())
+			assert.True(t, result.closed.Load())
+		})
+	}
+}
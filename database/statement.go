@@ -0,0 +1,57 @@
+package database
+
+import (
+	"github.com/gulfstream-h/ksql/internal/util"
+	"slices"
+	"strings"
+)
+
+// readStatements - statements, that don't change
+// server state, so they can be safely repeated
+var readStatements = []string{
+	"LIST",
+	"SHOW",
+	"DESCRIBE",
+	"EXPLAIN",
+}
+
+// isReadStatement - checks if query only reads metadata
+func isReadStatement(query string) bool {
+	verb := firstWord(query)
+
+	for _, statement := range readStatements {
+		if verb == statement {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPullQuery - checks if query is select
+// without EMIT CHANGES or EMIT FINAL clause
+func isPullQuery(query string) bool {
+	return firstWord(query) == "SELECT" && !hasKeyword(query, "EMIT")
+}
+
+// isLimited - checks if query has LIMIT clause,
+// so server closes stream after last row
+func isLimited(query string) bool {
+	return hasKeyword(query, "LIMIT")
+}
+
+// hasKeyword - checks if query contains keyword outside
+// of string literals, which are redacted before search
+func hasKeyword(query, keyword string) bool {
+	return slices.Contains(strings.Fields(strings.ToUpper(util.RedactStatement(query))), keyword)
+}
+
+// firstWord - returns upper-cased statement keyword
+func firstWord(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return strings.ToUpper(strings.TrimSuffix(fields[0], ";"))
+}
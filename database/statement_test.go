@@ -0,0 +1,58 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_StatementKind(t *testing.T) {
+	testcases := []struct {
+		name        string
+		query       string
+		wantRead    bool
+		wantPull    bool
+		wantLimited bool
+	}{
+		{
+			name:     "Metadata listing",
+			query:    "SHOW STREAMS;",
+			wantRead: true,
+		},
+		{
+			name:     "Pull query",
+			query:    "SELECT ID FROM USERS WHERE ID = 1;",
+			wantPull: true,
+		},
+		{
+			name:  "Push query",
+			query: "SELECT ID FROM USERS EMIT CHANGES;",
+		},
+		{
+			name:        "Limited push query",
+			query:       "select id from users emit changes\nlimit 5;",
+			wantLimited: true,
+		},
+		{
+			name:     "Keywords inside string literal",
+			query:    "SELECT ID FROM USERS WHERE NAME = 'EMIT CHANGES LIMIT 5';",
+			wantPull: true,
+		},
+		{
+			name:  "Keyword inside escaped string literal",
+			query: "SELECT ID FROM USERS WHERE NAME = 'O''Brien LIMIT 5' EMIT CHANGES;",
+		},
+		{
+			name:     "Keyword as part of identifier",
+			query:    "SELECT SUBMIT_TIME, LIMITS FROM USERS;",
+			wantPull: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantRead, isReadStatement(tc.query))
+			assert.Equal(t, tc.wantPull, isPullQuery(tc.query))
+			assert.Equal(t, tc.wantLimited, isLimited(tc.query))
+		})
+	}
+}
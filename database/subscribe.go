@@ -0,0 +1,183 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"github.com/gulfstream-h/ksql/internal/kernel/network"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+)
+
+const (
+	// ContinuationTokensEnabled - streams property, that makes
+	// scalable push query emit continuation tokens. Requires
+	// ksql.query.push.v2.enabled on server
+	ContinuationTokensEnabled = "ksql.query.push.v2.continuation.tokens.enabled"
+
+	// continuationTokenProperty - request property, that
+	// resumes scalable push query after the token position
+	continuationTokenProperty = "request.ksql.query.push.continuation.token"
+)
+
+// ReconnectPolicy - describes how dropped push subscription
+// is restored. Zero pauses are replaced with ones of
+// DefaultReconnectPolicy, so zero value reconnects forever
+// without hammering the cluster
+type ReconnectPolicy struct {
+	MaxAttempts    int           // consecutive attempts without received row, zero means unlimited
+	InitialBackoff time.Duration // pause before second attempt
+	MaxBackoff     time.Duration // upper limit of growing pause
+}
+
+// DefaultReconnectPolicy - keeps subscription
+// alive across restarts of ksqlDB nodes
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// backoff - pauses between attempts, zero
+// ones are taken from default policy
+func (p ReconnectPolicy) backoff() network.RetryPolicy {
+	var (
+		defaults = DefaultReconnectPolicy()
+		backoff  = network.RetryPolicy{
+			InitialBackoff: p.InitialBackoff,
+			MaxBackoff:     p.MaxBackoff,
+		}
+	)
+
+	if backoff.InitialBackoff <= 0 {
+		backoff.InitialBackoff = defaults.InitialBackoff
+	}
+
+	if backoff.MaxBackoff <= 0 {
+		backoff.MaxBackoff = max(defaults.MaxBackoff, backoff.InitialBackoff)
+	}
+
+	return backoff
+}
+
+// ReconnectEvent - attempt of restoring dropped push query
+type ReconnectEvent struct {
+	Attempt int    // number of attempt since last received row
+	Host    string // node, that runs restored query
+	QueryID string // identifier of restored query
+	Resumed bool   // query is continued from the last received token
+	Err     error  // reason of failed attempt, nil if query is restored
+}
+
+// Subscribe - performs push query, which outlives dropped
+// connections. Query is re-issued according to reconnect
+// policy (DefaultReconnectPolicy, if not passed). When
+// server emits continuation tokens, query is resumed after
+// last received row, otherwise rows emitted during
+// reconnect are missed or repeated depending on auto.offset.reset
+func Subscribe[S any](
+	ctx context.Context,
+	query string,
+	opts ...Option,
+) (*Result[S], error) {
+
+	o := newOptions(opts...)
+	if o.reconnect == nil {
+		opts = append(slices.Clone(opts), WithReconnectPolicy(DefaultReconnectPolicy()))
+	}
+
+	return Select[S](ctx, query, opts...)
+}
+
+// reopen - restores dropped push query. Pause precedes every
+// attempt, so stream, that drops right after reopening, doesn't
+// spin. Attempts are counted by caller across reopened streams.
+// Without reconnect policy query is re-issued once per drop with
+// pause and retries of client retry policy. Returns attempts count
+func reopen[S any](
+	ctx context.Context,
+	client *Client,
+	query string,
+	o options,
+	result *Result[S],
+	attempts int,
+	cause error,
+) (queryStream, int, error) {
+
+	var (
+		maxAttempts = 1
+		backoff     = client.net.RetryPolicy()
+	)
+
+	if o.reconnect != nil {
+		maxAttempts = o.reconnect.MaxAttempts
+		backoff = o.reconnect.backoff()
+	}
+
+	for {
+		if maxAttempts > 0 && attempts >= maxAttempts {
+			return queryStream{}, attempts, fmt.Errorf(
+				"push query is not reconnected after %d attempts: %w", attempts, cause)
+		}
+
+		timer := time.NewTimer(backoff.Backoff(attempts))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return queryStream{}, attempts, ctx.Err()
+		case <-timer.C:
+		}
+
+		attempts++
+
+		props := o.properties
+
+		token := result.ContinuationToken()
+		if len(token) != 0 {
+			props.Request = maps.Clone(props.Request)
+			if props.Request == nil {
+				props.Request = make(map[string]any)
+			}
+			props.Request[continuationTokenProperty] = token
+		}
+
+		stream, err := client.openStream(ctx, query, props, o.reconnect == nil)
+		if err == nil {
+			o.report(ReconnectEvent{
+				Attempt: attempts,
+				Host:    stream.host,
+				QueryID: stream.header.QueryID,
+				Resumed: len(token) != 0,
+			})
+			return stream, attempts, nil
+		}
+
+		if ctx.Err() != nil {
+			return stream, attempts, err
+		}
+
+		client.Logger().Warn(
+			"push query is not reconnected",
+			slog.Int("attempt", attempts),
+			slog.String("error", err.Error()),
+		)
+
+		o.report(ReconnectEvent{
+			Attempt: attempts,
+			Resumed: len(token) != 0,
+			Err:     err,
+		})
+
+		cause = err
+	}
+}
+
+// report - passes reconnect event to handler, if it's set
+func (o options) report(event ReconnectEvent) {
+	if o.onReconnect != nil {
+		o.onReconnect(event)
+	}
+}
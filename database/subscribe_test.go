@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"github.com/gulfstream-h/ksql/consts"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_ReconnectBackoff(t *testing.T) {
+	defaults := DefaultReconnectPolicy()
+
+	testcases := []struct {
+		name        string
+		policy      ReconnectPolicy
+		wantInitial time.Duration
+		wantMax     time.Duration
+	}{
+		{
+			name:        "Zero policy uses default pauses",
+			policy:      ReconnectPolicy{},
+			wantInitial: defaults.InitialBackoff,
+			wantMax:     defaults.MaxBackoff,
+		},
+		{
+			name:        "Attempts limit keeps default pauses",
+			policy:      ReconnectPolicy{MaxAttempts: 3},
+			wantInitial: defaults.InitialBackoff,
+			wantMax:     defaults.MaxBackoff,
+		},
+		{
+			name:        "Custom pauses",
+			policy:      ReconnectPolicy{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second},
+			wantInitial: time.Second,
+			wantMax:     10 * time.Second,
+		},
+		{
+			name:        "Initial pause above default limit",
+			policy:      ReconnectPolicy{InitialBackoff: time.Minute},
+			wantInitial: time.Minute,
+			wantMax:     time.Minute,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			backoff := tc.policy.backoff()
+			assert.Equal(t, tc.wantInitial, backoff.InitialBackoff)
+			assert.Equal(t, tc.wantMax, backoff.MaxBackoff)
+			assert.Positive(t, backoff.Backoff(0))
+		})
+	}
+}
+
+// resumableServer - serves push query, which connection is
+// dropped after first continuation token, and records
+// tokens, that reconnected queries are resumed with
+type resumableServer struct {
+	mu      sync.Mutex
+	queries int
+	tokens  []any
+}
+
+func (rs *resumableServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == consts.CloseQueryRoute {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var (
+		body struct {
+			Request map[string]any `json:"requestProperties"`
+		}
+	)
+
+	if err := jsoniter.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	rs.mu.Lock()
+	rs.queries++
+	query := rs.queries
+	rs.tokens = append(rs.tokens, body.Request[continuationTokenProperty])
+	rs.mu.Unlock()
+
+	w.Header().Set(consts.ContentType, consts.HeaderDelimited)
+	w.WriteHeader(http.StatusOK)
+
+	if query == 1 {
+		w.Write([]byte(`{"queryId":"query_1","columnNames":["ID"],"columnTypes":["INTEGER"]}` + "\n"))
+		w.Write([]byte(`[1]` + "\n"))
+		w.Write([]byte(`{"continuationToken":"token_1"}` + "\n"))
+		w.(http.Flusher).Flush()
+		// connection is broken
+		panic(http.ErrAbortHandler)
+	}
+
+	w.Write([]byte(`{"queryId":"query_2","columnNames":["ID"],"columnTypes":["INTEGER"]}` + "\n"))
+	w.Write([]byte(`[2]` + "\n"))
+	w.(http.Flusher).Flush()
+
+	<-r.Context().Done()
+}
+
+func Test_SubscribeResume(t *testing.T) {
+	var (
+		handler = &resumableServer{}
+		client  = streamClient(t, handler)
+		events  = make(chan ReconnectEvent, 1)
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := Subscribe[struct {
+		ID int `ksql:"ID"`
+	}](
+		ctx,
+		"SELECT ID FROM NUMBERS EMIT CHANGES;",
+		WithClient(client),
+		WithReconnectPolicy(ReconnectPolicy{InitialBackoff: time.Millisecond}),
+		OnReconnect(func(event ReconnectEvent) {
+			events <- event
+		}),
+	)
+	assert.NoError(t, err)
+
+	first := <-result.Values()
+	assert.Equal(t, 1, first.ID)
+
+	second := <-result.Values()
+	assert.Equal(t, 2, second.ID)
+
+	event := <-events
+	assert.Equal(t, 1, event.Attempt)
+	assert.Equal(t, "query_2", event.QueryID)
+	assert.True(t, event.Resumed)
+	assert.NoError(t, event.Err)
+
+	assert.Equal(t, "query_2", result.ID())
+	assert.Equal(t, "token_1", result.ContinuationToken())
+
+	handler.mu.Lock()
+	assert.Equal(t, []any{nil, "token_1"}, handler.tokens)
+	handler.mu.Unlock()
+
+	assert.NoError(t, result.Close())
+
+	for range result.Values() {
+	}
+	assert.Equal(t, Closed, result.Reason())
+}
@@ -0,0 +1,92 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ksqlDB error codes are http status multiplied
+// by 100 with statement-specific suffix
+const (
+	CodeBadRequest        = 40000
+	CodeBadStatement      = 40001
+	CodeUnauthorized      = 40100
+	CodeForbidden         = 40300
+	CodeNotFound          = 40400
+	CodeServerError       = 50000
+	CodeServerUnavailable = 50300
+)
+
+var (
+	// sentinels to match KsqlError with errors.Is
+
+	ErrNotFound          = errors.New("ksql entity not found")
+	ErrAlreadyExists     = errors.New("ksql entity already exists")
+	ErrBadStatement      = errors.New("ksql statement is rejected")
+	ErrServerUnavailable = errors.New("ksql server is unavailable")
+	ErrUnauthorized      = errors.New("ksql request is not authorized")
+)
+
+// KsqlError - error, returned by ksqlDB server
+// in KsqlErrorMessage or KsqlStatementErrorMessage body.
+// It is matched with package sentinels via errors.Is
+type KsqlError struct {
+	StatusCode int               // http status, zero for errors inside query stream
+	Code       int               // ksqlDB error_code
+	Message    string            // server message
+	Statement  string            // rejected statement text
+	Entities   []json.RawMessage // entities, reported with statement error
+	StackTrace []string          // server stack trace
+}
+
+// Error - formats ksql error
+func (e *KsqlError) Error() string {
+	if e.Code == 0 {
+		return fmt.Sprintf("ksql server responded with status %d: %s",
+			e.StatusCode, e.Message)
+	}
+
+	if len(e.Statement) != 0 {
+		return fmt.Sprintf("ksql error %d: %s (statement: %s)",
+			e.Code, e.Message, e.Statement)
+	}
+
+	return fmt.Sprintf("ksql error %d: %s", e.Code, e.Message)
+}
+
+// Is - matches error with sentinels by code and message.
+// ksqlDB reports missing and duplicated relations as
+// bad statements, so their messages are checked too
+func (e *KsqlError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Code == CodeNotFound ||
+			e.StatusCode == http.StatusNotFound ||
+			e.isStatementError() && (strings.Contains(e.Message, "does not exist") ||
+				strings.Contains(e.Message, "Could not find"))
+	case ErrAlreadyExists:
+		return e.isStatementError() && strings.Contains(e.Message, "already exists")
+	case ErrBadStatement:
+		return e.isStatementError()
+	case ErrServerUnavailable:
+		return e.Code/100 == CodeServerUnavailable/100 ||
+			e.StatusCode == http.StatusServiceUnavailable ||
+			e.StatusCode == http.StatusBadGateway ||
+			e.StatusCode == http.StatusGatewayTimeout
+	case ErrUnauthorized:
+		return e.Code/100 == CodeUnauthorized/100 ||
+			e.Code/100 == CodeForbidden/100 ||
+			e.StatusCode == http.StatusUnauthorized ||
+			e.StatusCode == http.StatusForbidden
+	}
+
+	return false
+}
+
+// isStatementError - reports if server rejected statement itself
+func (e *KsqlError) isStatementError() bool {
+	return e.Code == CodeBadStatement || e.Code == CodeBadRequest
+}
@@ -0,0 +1,117 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func Test_KsqlErrorIs(t *testing.T) {
+	sentinels := []error{
+		ErrNotFound,
+		ErrAlreadyExists,
+		ErrBadStatement,
+		ErrServerUnavailable,
+		ErrUnauthorized,
+	}
+
+	testcases := []struct {
+		name     string
+		err      *KsqlError
+		expected []error
+	}{
+		{
+			name:     "Missing relation statement",
+			err:      &KsqlError{StatusCode: http.StatusBadRequest, Code: CodeBadStatement, Message: "Stream ORDERS does not exist."},
+			expected: []error{ErrNotFound, ErrBadStatement},
+		},
+		{
+			name:     "Unknown source of select",
+			err:      &KsqlError{StatusCode: http.StatusBadRequest, Code: CodeBadRequest, Message: "Could not find STREAM/TABLE 'ORDERS' in the Metastore"},
+			expected: []error{ErrNotFound, ErrBadStatement},
+		},
+		{
+			name:     "Not found code",
+			err:      &KsqlError{StatusCode: http.StatusNotFound, Code: CodeNotFound, Message: "Query not found"},
+			expected: []error{ErrNotFound},
+		},
+		{
+			name:     "Duplicated relation",
+			err:      &KsqlError{StatusCode: http.StatusBadRequest, Code: CodeBadStatement, Message: "Cannot add stream 'ORDERS': A stream with the same name already exists"},
+			expected: []error{ErrAlreadyExists, ErrBadStatement},
+		},
+		{
+			name:     "Syntax error",
+			err:      &KsqlError{StatusCode: http.StatusBadRequest, Code: CodeBadStatement, Message: "line 1:8: Syntax Error"},
+			expected: []error{ErrBadStatement},
+		},
+		{
+			name:     "Unavailable server code",
+			err:      &KsqlError{StatusCode: http.StatusServiceUnavailable, Code: 50304, Message: "Server is not ready"},
+			expected: []error{ErrServerUnavailable},
+		},
+		{
+			name:     "Proxy gateway error",
+			err:      &KsqlError{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"},
+			expected: []error{ErrServerUnavailable},
+		},
+		{
+			name:     "Unauthorized request",
+			err:      &KsqlError{StatusCode: http.StatusUnauthorized, Code: CodeUnauthorized, Message: "Unauthorized"},
+			expected: []error{ErrUnauthorized},
+		},
+		{
+			name:     "Forbidden topic",
+			err:      &KsqlError{StatusCode: http.StatusForbidden, Code: CodeForbidden, Message: "Authorization denied"},
+			expected: []error{ErrUnauthorized},
+		},
+		{
+			name: "Internal server error",
+			err:  &KsqlError{StatusCode: http.StatusInternalServerError, Code: CodeServerError, Message: "NullPointerException"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// errors are matched through wrapping as well
+			wrapped := fmt.Errorf("cannot execute statement: %w", tc.err)
+
+			for _, sentinel := range sentinels {
+				expected := errors.Is(errors.Join(tc.expected...), sentinel)
+				assert.Equal(t, expected, errors.Is(wrapped, sentinel), sentinel.Error())
+			}
+		})
+	}
+}
+
+func Test_KsqlErrorMessage(t *testing.T) {
+	testcases := []struct {
+		name     string
+		err      *KsqlError
+		expected string
+	}{
+		{
+			name:     "Proxy error",
+			err:      &KsqlError{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"},
+			expected: "ksql server responded with status 502: Bad Gateway",
+		},
+		{
+			name:     "Statement error",
+			err:      &KsqlError{Code: CodeBadStatement, Message: "Syntax Error", Statement: "SELEC 1;"},
+			expected: "ksql error 40001: Syntax Error (statement: SELEC 1;)",
+		},
+		{
+			name:     "Server error",
+			err:      &KsqlError{Code: CodeServerError, Message: "NullPointerException"},
+			expected: "ksql error 50000: NullPointerException",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.err.Error())
+		})
+	}
+}
@@ -7,10 +7,19 @@ import (
 var (
 	ErrMissingHost             = errors.New("missing ksql host")
 	ErrTimeoutIsZeroOrNegative = errors.New("await timeout cannot be equal or less then zero")
+	ErrClientNotConfigured     = errors.New("ksql client is not configured")
+	ErrConflictingAuth         = errors.New("only one authentication method can be configured")
 
 	ErrStreamDoesNotExist = errors.New("stream does not exist")
 	ErrTableDoesNotExist  = errors.New("table does not exist")
+	ErrMissingPrimaryKey  = errors.New("relation structure has no primary key field")
+	ErrRowNotFound        = errors.New("no rows match pull query")
 
 	ErrMalformedResponse      = errors.New("unprocessable ksql response")
 	ErrUnserializableResponse = errors.New("unserializable ksql response")
+	ErrBrokenStream           = errors.New("ksql response is broken before its end")
+
+	ErrBufferOverflow = errors.New("select values buffer overflow")
+
+	ErrArgumentsMismatch = errors.New("count of placeholders and arguments doesn't match")
 )
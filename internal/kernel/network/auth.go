@@ -0,0 +1,133 @@
+package network
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// tokenRefreshMargin - refreshing tokens are renewed
+	// a bit before expiration to avoid races with server clock
+	tokenRefreshMargin = 30 * time.Second
+)
+
+// Authenticator - applies credentials to every
+// outgoing request to ksqlDB server
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// BasicAuth - http basic authentication
+// used by on-prem ksqlDB with JAAS realm
+// and by Confluent Cloud API keys
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// Authenticate - sets basic credentials to request
+func (ba BasicAuth) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(ba.Username, ba.Password)
+	return nil
+}
+
+// BearerToken - static bearer token authentication
+type BearerToken struct {
+	Token string
+}
+
+// Authenticate - sets bearer token to request
+func (bt BearerToken) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+bt.Token)
+	return nil
+}
+
+// TokenSource - provides bearer token with its expiration time.
+// Zero expiration means that token is requested for every call
+type TokenSource func(ctx context.Context) (token string, expiry time.Time, err error)
+
+// RefreshingToken - bearer token authentication, where token
+// is received from source and cached till expiration
+type RefreshingToken struct {
+	source TokenSource
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewRefreshingToken - constructor for bearer token with renewal
+func NewRefreshingToken(source TokenSource) *RefreshingToken {
+	return &RefreshingToken{
+		source: source,
+	}
+}
+
+// Authenticate - sets cached or renewed token to request
+func (rt *RefreshingToken) Authenticate(req *http.Request) error {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if len(rt.token) == 0 || time.Now().Add(tokenRefreshMargin).After(rt.expiry) {
+		token, expiry, err := rt.source(req.Context())
+		if err != nil {
+			return fmt.Errorf("cannot refresh token: %w", err)
+		}
+
+		rt.token = token
+		rt.expiry = expiry
+	}
+
+	req.Header.Set("Authorization", "Bearer "+rt.token)
+	return nil
+}
+
+// NewTLSConfig - builds tls settings for mutual authentication.
+// Client certificate is optional, but requires both files.
+// Custom CA replaces system pool for server verification
+func NewTLSConfig(
+	certFile string,
+	keyFile string,
+	caFile string,
+) (*tls.Config, error) {
+
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if len(certFile) != 0 || len(keyFile) != 0 {
+		if len(certFile) == 0 || len(keyFile) == 0 {
+			return nil, errors.New("client certificate requires both cert and key files")
+		}
+
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load client certificate: %w", err)
+		}
+
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(caFile) != 0 {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("CA file doesn't contain any valid certificate")
+		}
+
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
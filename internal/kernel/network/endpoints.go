@@ -0,0 +1,217 @@
+package network
+
+import (
+	"context"
+	"github.com/gulfstream-h/ksql/consts"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dao"
+	jsoniter "github.com/json-iterator/go"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// failedCooldown - pause, after which failed node becomes
+	// eligible again, even if health checks are disabled
+	failedCooldown = 30 * time.Second
+)
+
+// endpoint - single ksqlDB server of cluster
+type endpoint struct {
+	host    string
+	addr    string // host:port, as it's reported by cluster status
+	healthy atomic.Bool
+
+	// failedAt - unix nano time, when node was marked unhealthy
+	failedAt atomic.Int64
+}
+
+// endpoints - pool of cluster nodes. Requests are sticky
+// to current node till it fails, then next healthy one is chosen
+type endpoints struct {
+	list    []*endpoint
+	current atomic.Int64
+	logger  *slog.Logger
+}
+
+// newEndpoints - builds pool, all nodes are considered healthy
+func newEndpoints(hosts []string, logger *slog.Logger) *endpoints {
+	pool := &endpoints{
+		logger: logger,
+	}
+
+	for _, host := range hosts {
+		ep := &endpoint{
+			host: strings.TrimSuffix(host, "/"),
+		}
+
+		if parsed, err := url.Parse(ep.host); err == nil {
+			ep.addr = parsed.Host
+		}
+
+		ep.healthy.Store(true)
+		pool.list = append(pool.list, ep)
+	}
+
+	return pool
+}
+
+// pick - returns current node if it's available or fails
+// over to next available one. When whole cluster is down,
+// node, that failed the earliest, is returned, so caller
+// gets real error and nodes are tried in turn
+func (e *endpoints) pick() (*endpoint, error) {
+	if len(e.list) == 0 {
+		return nil, libErrors.ErrMissingHost
+	}
+
+	var (
+		current = e.current.Load()
+		now     = time.Now()
+		oldest  = current
+	)
+
+	for i := range e.list {
+		idx := (current + int64(i)) % int64(len(e.list))
+		if e.list[idx].available(now) {
+			e.current.CompareAndSwap(current, idx)
+			return e.list[idx], nil
+		}
+
+		if e.list[idx].failedAt.Load() < e.list[oldest].failedAt.Load() {
+			oldest = idx
+		}
+	}
+
+	e.current.CompareAndSwap(current, oldest)
+	return e.list[oldest], nil
+}
+
+// fail - marks node as unavailable till next successful
+// probe or till cooldown is over
+func (e *endpoints) fail(ep *endpoint) {
+	if len(e.list) > 1 && ep.markFailed() {
+		e.log().Warn("ksql node is unavailable, failing over", slog.String("host", ep.host))
+	}
+}
+
+// restore - returns node to rotation after
+// successful probe or request
+func (e *endpoints) restore(ep *endpoint) {
+	if !ep.healthy.Swap(true) {
+		e.log().Info("ksql node is available again", slog.String("host", ep.host))
+	}
+}
+
+// available - checks if node is healthy or
+// its failure is old enough to try it again
+func (ep *endpoint) available(now time.Time) bool {
+	return ep.healthy.Load() || now.Sub(time.Unix(0, ep.failedAt.Load())) >= failedCooldown
+}
+
+// markFailed - takes node out of rotation for cooldown.
+// Returns true, if node was considered healthy
+func (ep *endpoint) markFailed() bool {
+	ep.failedAt.Store(time.Now().UnixNano())
+	return ep.healthy.Swap(false)
+}
+
+// log - returns configured or default logger
+func (e *endpoints) log() *slog.Logger {
+	if e.logger == nil {
+		return slog.Default()
+	}
+	return e.logger
+}
+
+// healthCheck - periodically probes every node with
+// /healthcheck and corrects their state with /clusterStatus,
+// reported by any healthy node
+func (n *Transport) healthCheck(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var (
+			reporter *endpoint
+		)
+
+		for _, ep := range n.endpoints.list {
+			var (
+				health dao.HealthCheck
+			)
+
+			healthy := n.probe(ctx, ep, consts.HealthCheckRoute, &health) && health.IsHealthy
+			if healthy {
+				n.endpoints.restore(ep)
+			} else {
+				ep.markFailed()
+			}
+
+			if healthy && reporter == nil {
+				reporter = ep
+			}
+		}
+
+		if reporter == nil {
+			continue
+		}
+
+		var (
+			status dao.ClusterStatus
+		)
+
+		// cluster status requires heartbeat to be enabled on servers
+		if !n.probe(ctx, reporter, consts.ClusterRoute, &status) {
+			continue
+		}
+
+		for _, ep := range n.endpoints.list {
+			if node, ok := status.ClusterStatus[ep.addr]; ok && !node.HostAlive {
+				ep.markFailed()
+			}
+		}
+	}
+}
+
+// probe - performs GET request to node and decodes response
+func (n *Transport) probe(
+	ctx context.Context,
+	ep *endpoint,
+	route string,
+	dst any,
+) bool {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep.host+route, nil)
+	if err != nil {
+		return false
+	}
+
+	if err = n.authenticate(req); err != nil {
+		return false
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return false
+	}
+
+	return jsoniter.Unmarshal(body, dst) == nil
+}
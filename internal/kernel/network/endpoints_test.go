@@ -0,0 +1,103 @@
+package network
+
+import (
+	"context"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_EndpointsPick(t *testing.T) {
+	var (
+		now    = time.Now()
+		recent = now.UnixNano()
+		stale  = now.Add(-2 * failedCooldown).UnixNano()
+	)
+
+	testcases := []struct {
+		name     string
+		current  int64
+		healthy  []bool
+		failedAt []int64
+		expected string
+	}{
+		{
+			name:     "Current healthy node is sticky",
+			current:  1,
+			healthy:  []bool{true, true, true},
+			failedAt: []int64{0, 0, 0},
+			expected: "http://node-1",
+		},
+		{
+			name:     "Failover to next healthy node",
+			healthy:  []bool{false, false, true},
+			failedAt: []int64{recent, recent, 0},
+			expected: "http://node-2",
+		},
+		{
+			name:     "Failed node is eligible after cooldown",
+			healthy:  []bool{false, false, false},
+			failedAt: []int64{recent, stale, recent},
+			expected: "http://node-1",
+		},
+		{
+			name:     "Earliest failed node is tried, when cluster is down",
+			healthy:  []bool{false, false, false},
+			failedAt: []int64{recent, recent + 2, recent + 1},
+			expected: "http://node-0",
+		},
+		{
+			name:     "Nodes are tried in turn, when cluster is down",
+			current:  0,
+			healthy:  []bool{false, false, false},
+			failedAt: []int64{recent + 2, recent, recent + 1},
+			expected: "http://node-1",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := newEndpoints([]string{"http://node-0", "http://node-1/", "http://node-2"}, nil)
+			pool.current.Store(tc.current)
+
+			for idx, ep := range pool.list {
+				ep.healthy.Store(tc.healthy[idx])
+				ep.failedAt.Store(tc.failedAt[idx])
+			}
+
+			picked, err := pool.pick()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, picked.host)
+		})
+	}
+}
+
+func Test_EndpointsFail(t *testing.T) {
+	pool := newEndpoints([]string{"http://node-0", "http://node-1"}, nil)
+
+	first, _ := pool.pick()
+	pool.fail(first)
+	assert.False(t, first.healthy.Load())
+
+	next, _ := pool.pick()
+	assert.Equal(t, "http://node-1", next.host)
+
+	pool.restore(first)
+	assert.True(t, first.healthy.Load())
+
+	// single node is never taken out of rotation
+	single := newEndpoints([]string{"http://node-0"}, nil)
+	single.fail(single.list[0])
+	assert.True(t, single.list[0].healthy.Load())
+}
+
+func Test_EndpointsEmpty(t *testing.T) {
+	picked, err := newEndpoints(nil, nil).pick()
+	assert.Nil(t, picked)
+	assert.ErrorIs(t, err, libErrors.ErrMissingHost)
+
+	transport := New(Settings{})
+	_, _, err = transport.PerformQueryStream(context.Background(), "SELECT 1;", Properties{}, Delimited{})
+	assert.ErrorIs(t, err, libErrors.ErrMissingHost)
+}
@@ -0,0 +1,128 @@
+package network
+
+import (
+	"context"
+	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dao"
+	"github.com/gulfstream-h/ksql/shared"
+	jsoniter "github.com/json-iterator/go"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// chain - registered interceptors of transport
+type chain []shared.Interceptor
+
+// observation - statement in flight, that
+// is reported to interceptors once finished
+type observation struct {
+	ctx    context.Context
+	stmt   *shared.Statement
+	chain  chain
+	status int
+	once   sync.Once
+}
+
+// start - notifies interceptors about new request.
+// Returns nil observation, when there are no interceptors
+func (c chain) start(req *http.Request, query string) (*http.Request, *observation) {
+	if len(c) == 0 {
+		return req, nil
+	}
+
+	obs := &observation{
+		ctx: req.Context(),
+		stmt: &shared.Statement{
+			Route:  req.URL.Path,
+			Query:  query,
+			Host:   req.URL.Host,
+			Header: make(http.Header),
+			Start:  time.Now(),
+		},
+		chain: c,
+	}
+
+	for _, interceptor := range c {
+		obs.ctx = interceptor.Before(obs.ctx, obs.stmt)
+	}
+
+	req = req.WithContext(obs.ctx)
+	for key, values := range obs.stmt.Header {
+		req.Header[key] = values
+	}
+
+	return req, obs
+}
+
+// finish - reports outcome to interceptors in reverse order
+func (o *observation) finish(outcome shared.Outcome) {
+	if o == nil {
+		return
+	}
+
+	o.once.Do(func() {
+		if outcome.Status == 0 {
+			outcome.Status = o.status
+		}
+		outcome.Duration = time.Since(o.stmt.Start)
+
+		for i := len(o.chain) - 1; i >= 0; i-- {
+			o.chain[i].After(o.ctx, o.stmt, outcome)
+		}
+	})
+}
+
+// watch - counts rows of query stream and reports
+// outcome, when stream is finished or abandoned
+func (o *observation) watch(
+	ctx context.Context,
+	frames <-chan []byte,
+) <-chan []byte {
+
+	if o == nil {
+		return frames
+	}
+
+	watched := make(chan []byte)
+
+	go func() {
+		defer close(watched)
+
+		var (
+			outcome shared.Outcome
+		)
+		defer func() {
+			o.finish(outcome)
+		}()
+
+		for frame := range frames {
+			switch {
+			case len(frame) != 0 && frame[0] == '[':
+				outcome.Rows++
+			case outcome.Err == nil:
+				var (
+					msg dao.ErrorMessage
+				)
+
+				if jsoniter.Unmarshal(frame, &msg) != nil {
+					break
+				}
+
+				if msg.Broken() {
+					outcome.Err = msg.BrokenErr()
+				} else if msg.ErrorCode != 0 {
+					outcome.Err = msg.Err(0)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				outcome.Err = ctx.Err()
+				return
+			case watched <- frame:
+			}
+		}
+	}()
+
+	return watched
+}
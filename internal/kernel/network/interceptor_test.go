@@ -0,0 +1,160 @@
+package network
+
+import (
+	"context"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/gulfstream-h/ksql/shared"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// journal - records calls of interceptors in order
+type journal struct {
+	mu       sync.Mutex
+	calls    []string
+	outcomes []shared.Outcome
+	done     chan struct{}
+}
+
+// recorder - interceptor, that writes its calls to journal
+type recorder struct {
+	name    string
+	journal *journal
+}
+
+func (r recorder) Before(ctx context.Context, stmt *shared.Statement) context.Context {
+	r.journal.mu.Lock()
+	defer r.journal.mu.Unlock()
+
+	r.journal.calls = append(r.journal.calls, "before "+r.name)
+	stmt.Header.Add("X-Interceptor", r.name)
+
+	return ctx
+}
+
+func (r recorder) After(ctx context.Context, stmt *shared.Statement, outcome shared.Outcome) {
+	r.journal.mu.Lock()
+	defer r.journal.mu.Unlock()
+
+	r.journal.calls = append(r.journal.calls, "after "+r.name)
+	r.journal.outcomes = append(r.journal.outcomes, outcome)
+
+	if len(r.journal.outcomes) == 2 {
+		close(r.journal.done)
+	}
+}
+
+func Test_InterceptorChain(t *testing.T) {
+	const (
+		header = `{"queryId":"query_1","columnNames":["ID"],"columnTypes":["INTEGER"]}` + "\n"
+	)
+
+	testcases := []struct {
+		name       string
+		stream     bool
+		status     int
+		body       string
+		wantRows   int64
+		wantErr    error
+		wantStatus int
+	}{
+		{
+			name:       "Successful statement",
+			status:     http.StatusOK,
+			body:       `[{"@type":"currentStatus","commandSequenceNumber":1}]`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Rejected statement",
+			status:     http.StatusBadRequest,
+			body:       `{"@type":"statement_error","error_code":40001,"message":"Syntax Error"}`,
+			wantErr:    libErrors.ErrBadStatement,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Query stream rows",
+			stream:     true,
+			status:     http.StatusOK,
+			body:       header + "[1]\n[2]\n",
+			wantRows:   2,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Query stream error frame",
+			stream:     true,
+			status:     http.StatusOK,
+			body:       header + "[1]\n" + `{"@type":"generic_error","error_code":40400,"message":"Query not found"}` + "\n",
+			wantRows:   1,
+			wantErr:    libErrors.ErrNotFound,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				log     = &journal{done: make(chan struct{})}
+				headers = make(chan []string, 1)
+			)
+
+			server := newServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				headers <- r.Header.Values("X-Interceptor")
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+
+			transport := New(Settings{
+				Hosts:   []string{server.URL},
+				Timeout: time.Second,
+				Interceptors: []shared.Interceptor{
+					recorder{name: "first", journal: log},
+					recorder{name: "second", journal: log},
+				},
+			})
+
+			var (
+				frames <-chan []byte
+				err    error
+			)
+
+			if tc.stream {
+				frames, _, err = transport.PerformQueryStream(context.Background(), "SELECT ID FROM NUMBERS;", Properties{}, Delimited{})
+			} else {
+				frames, err = transport.Perform(context.Background(), http.MethodPost, "SHOW STREAMS;", Properties{}, ShortPolling{})
+			}
+
+			// statement error is returned to caller,
+			// stream error is a frame of response
+			if tc.wantErr != nil && !tc.stream {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+				for range frames {
+				}
+			}
+
+			select {
+			case <-log.done:
+			case <-time.After(time.Second):
+				t.Fatal("outcome is not reported")
+			}
+
+			assert.Equal(t, []string{"first", "second"}, <-headers)
+			assert.Equal(t, []string{"before first", "before second", "after second", "after first"}, log.calls)
+
+			for _, outcome := range log.outcomes {
+				assert.Equal(t, tc.wantStatus, outcome.Status)
+				assert.Equal(t, tc.wantRows, outcome.Rows)
+
+				if tc.wantErr == nil {
+					assert.NoError(t, outcome.Err)
+				} else {
+					assert.ErrorIs(t, outcome.Err, tc.wantErr)
+				}
+			}
+		})
+	}
+}
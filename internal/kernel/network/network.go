@@ -4,68 +4,223 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"github.com/gulfstream-h/ksql/consts"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dao"
+	"github.com/gulfstream-h/ksql/shared"
 	jsoniter "github.com/json-iterator/go"
 	"io"
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 )
 
-var (
-	// Net - is a global ksql http proxy
-	// can be called in topics, streams and tables packages
-	Net network
-)
+// Transport - is ksql http proxy of single cluster.
+// It's owned by client and shared by topics, streams and tables packages
+type Transport struct {
+	endpoints    *endpoints
+	stop         context.CancelFunc
+	httpClient   *http.Client
+	pollClient   *http.Client
+	streamClient *http.Client
+	auth         Authenticator
+	retry        RetryPolicy
+	properties   Properties
+	interceptors chain
+}
 
-type network struct {
-	host       string
-	httpClient *http.Client
-	timeoutSec int64
+// Settings - describes connection to ksqlDB server
+type Settings struct {
+	Hosts       []string      // remote addresses of ksql cluster nodes
+	Timeout     time.Duration // short requests timeout
+	Auth        Authenticator // optional credentials applied to every request
+	TLS         *tls.Config   // optional client certificates and custom CA
+	Retry       RetryPolicy   // repeating of idempotent requests
+	HealthCheck time.Duration // nodes probing interval, zero disables probes
+	Properties  Properties    // defaults of every request
+
+	Interceptors []shared.Interceptor // observers of every statement
+	Logger       *slog.Logger         // destination of transport logs, default one if nil
 }
 
-// Init - entry point for all ksql usage
+// New - entry point for all ksql usage
 // it initiates http connection with ksql-client
-func Init(host string, timeout time.Duration) {
+func New(settings Settings) *Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = settings.TLS
+
 	client := http.Client{
-		Timeout: timeout,
+		Transport: transport,
+		Timeout:   settings.Timeout,
+	}
+
+	// long polling select keeps connection open,
+	// so there is no overall timeout, only context cancellation
+	pollClient := http.Client{
+		Transport: transport,
+	}
+
+	// query-stream endpoint is served over http/2.
+	// For plain http hosts prior knowledge (h2c) is used.
+	protocols := new(http.Protocols)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(true)
+
+	streamTransport := transport.Clone()
+	streamTransport.Protocols = protocols
+
+	streamClient := http.Client{
+		Transport: streamTransport,
+	}
+
+	n := &Transport{
+		endpoints:    newEndpoints(settings.Hosts, settings.Logger),
+		stop:         func() {},
+		httpClient:   &client,
+		pollClient:   &pollClient,
+		streamClient: &streamClient,
+		auth:         settings.Auth,
+		retry:        settings.Retry,
+		properties:   settings.Properties,
+		interceptors: settings.Interceptors,
+	}
+
+	// single node has nowhere to fail over,
+	// so probing it is useless
+	if len(settings.Hosts) > 1 && settings.HealthCheck > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		n.stop = cancel
+
+		go n.healthCheck(ctx, settings.HealthCheck)
+	}
+
+	return n
+}
+
+// Close - stops nodes health checking
+func (n *Transport) Close() {
+	n.stop()
+}
+
+// do - sends request to chosen node and reports it to
+// interceptors. Node is marked as unavailable, if it cannot be
+// reached or reports it's not ready. Unsuccessful responses are
+// decoded to errors. Observation must be finished by caller
+func (n *Transport) do(
+	client *http.Client,
+	ep *endpoint,
+	req *http.Request,
+	query string,
+) (*http.Response, *observation, error) {
+
+	req, obs := n.interceptors.start(req, query)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if req.Context().Err() == nil && ep != nil {
+			n.endpoints.fail(ep)
+		}
+
+		err = fmt.Errorf("error while performing req: %w", err)
+		obs.finish(shared.Outcome{Err: err})
+		return nil, nil, err
+	}
+
+	if resp.StatusCode == http.StatusServiceUnavailable && ep != nil {
+		n.endpoints.fail(ep)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		err = responseError(resp)
+		obs.finish(shared.Outcome{Status: resp.StatusCode, Err: err})
+		return nil, nil, err
+	}
+
+	if ep != nil {
+		n.endpoints.restore(ep)
+	}
+
+	if obs != nil {
+		obs.status = resp.StatusCode
+	}
+
+	return resp, obs, nil
+}
+
+// Retry - repeats attempt according to configured policy.
+// Callers decide if request is safe to be repeated
+func (n *Transport) Retry(
+	ctx context.Context,
+	attempt func() error,
+) error {
+	return n.retry.Do(ctx, attempt)
+}
+
+// RetryPolicy - returns configured retry policy
+func (n *Transport) RetryPolicy() RetryPolicy {
+	return n.retry
+}
+
+// authenticate - applies configured credentials to request
+func (n *Transport) authenticate(req *http.Request) error {
+	if n.auth == nil {
+		return nil
 	}
 
-	Net = network{
-		host:       host,
-		httpClient: &client,
+	if err := n.auth.Authenticate(req); err != nil {
+		return fmt.Errorf("error while authenticating req: %w", err)
 	}
+
+	return nil
 }
 
 // Poller - provides different strategies of kafka http processing
 // Like for short polling requests it handles only one full stream of data
 // For Long-Polling requests it provides row-by-row parsing with open connection
 // Mostly all ksql requests are short polling, however SELECT and SELECT WITH EMIT requests are
-// long polling
+// long polling. Response body is always closed by poller, and
+// its goroutine exits, when context is done, even if nobody reads channel
 type Poller interface {
-	Process(io.ReadCloser) <-chan []byte
+	Process(ctx context.Context, payload io.ReadCloser) <-chan []byte
 }
 
 // Perform - is common for all net request logic,
 // responsible to initiate and properly close connection
 // As all ksql queries shares the same http params,
 // current module sets it as default for code-reduce purpose
-func (n *network) Perform(
+func (n *Transport) Perform(
 	ctx context.Context,
 	method string,
 	query string,
+	props Properties,
 	pollingAlgo Poller) (<-chan []byte, error) {
 
+	ep, err := n.endpoints.pick()
+	if err != nil {
+		return nil, err
+	}
+
+	props = n.properties.merge(props)
+
 	q, _ := jsoniter.Marshal(struct {
-		KSQL string `json:"ksql"`
+		KSQL      string         `json:"ksql"`
+		Streams   map[string]any `json:"streamsProperties,omitempty"`
+		Variables map[string]any `json:"sessionVariables,omitempty"`
+		Sequence  int64          `json:"commandSequenceNumber,omitempty"`
 	}{
-		KSQL: query,
+		KSQL:      query,
+		Streams:   props.Streams,
+		Variables: props.Variables,
+		Sequence:  props.CommandSequence,
 	})
 
 	req, err := http.NewRequestWithContext(
 		ctx,
 		method,
-		n.host+consts.KsqlRoute,
+		ep.host+consts.KsqlRoute,
 		bytes.NewReader(q),
 	)
 	if err != nil {
@@ -77,35 +232,52 @@ func (n *network) Perform(
 		consts.HeaderKSQL,
 	)
 
-	var (
-		resp *http.Response
-	)
+	if err = n.authenticate(req); err != nil {
+		return nil, err
+	}
 
-	if resp, err = n.httpClient.Do(req); err != nil {
-		return nil, fmt.Errorf("error while performing req: %w", err)
+	resp, obs, err := n.do(n.httpClient, ep, req, query)
+	if err != nil {
+		return nil, err
 	}
 
-	return pollingAlgo.Process(resp.Body), nil
+	obs.finish(shared.Outcome{})
+
+	return pollingAlgo.Process(ctx, resp.Body), nil
 }
 
 // PerformSelect is used for long-living select queries
 // ksql requires asking websocket route for all selects
-func (n *network) PerformSelect(
+func (n *Transport) PerformSelect(
 	ctx context.Context,
 	method string,
 	query string,
+	props Properties,
 	pollingAlgo Poller) (<-chan []byte, error) {
 
+	ep, err := n.endpoints.pick()
+	if err != nil {
+		return nil, err
+	}
+
+	props = n.properties.merge(props)
+
 	q, _ := jsoniter.Marshal(struct {
-		KSQL string `json:"ksql"`
+		KSQL      string         `json:"ksql"`
+		Streams   map[string]any `json:"streamsProperties,omitempty"`
+		Variables map[string]any `json:"sessionVariables,omitempty"`
+		Sequence  int64          `json:"commandSequenceNumber,omitempty"`
 	}{
-		KSQL: query,
+		KSQL:      query,
+		Streams:   props.Streams,
+		Variables: props.Variables,
+		Sequence:  props.CommandSequence,
 	})
 
 	req, err := http.NewRequestWithContext(
 		ctx,
 		method,
-		n.host+consts.QueryRoute,
+		ep.host+consts.QueryRoute,
 		bytes.NewReader(q),
 	)
 	if err != nil {
@@ -116,17 +288,159 @@ func (n *network) PerformSelect(
 		consts.HeaderKSQL,
 	)
 
+	if err = n.authenticate(req); err != nil {
+		return nil, err
+	}
+
+	resp, obs, err := n.do(n.pollClient, ep, req, query)
+	if err != nil {
+		return nil, err
+	}
+
+	obs.finish(shared.Outcome{})
+
+	return pollingAlgo.Process(ctx, resp.Body), nil
+}
+
+// PerformQueryStream - is used for select queries over
+// http/2 /query-stream endpoint. Response is framed in
+// delimited format: header object comes first and
+// then every row is a separate json array on its own line.
+// Host of node, that runs the query, is returned as well
+func (n *Transport) PerformQueryStream(
+	ctx context.Context,
+	query string,
+	props Properties,
+	pollingAlgo Poller) (<-chan []byte, string, error) {
+
+	ep, err := n.endpoints.pick()
+	if err != nil {
+		return nil, "", err
+	}
+
+	props = n.properties.merge(props)
+
+	properties := props.Streams
+	if properties == nil {
+		properties = map[string]any{}
+	}
+
+	q, _ := jsoniter.Marshal(struct {
+		SQL        string         `json:"sql"`
+		Properties map[string]any `json:"properties"`
+		Variables  map[string]any `json:"sessionVariables,omitempty"`
+		Request    map[string]any `json:"requestProperties,omitempty"`
+	}{
+		SQL:        query,
+		Properties: properties,
+		Variables:  props.Variables,
+		Request:    props.Request,
+	})
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		ep.host+consts.QueryStreamRoute,
+		bytes.NewReader(q),
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("error while formating req: %w", err)
+	}
+
+	req.Header.Set(
+		consts.ContentType,
+		consts.HeaderJSON,
+	)
+	req.Header.Set(
+		consts.Accept,
+		consts.HeaderDelimited,
+	)
+
+	if err = n.authenticate(req); err != nil {
+		return nil, "", err
+	}
+
+	resp, obs, err := n.do(n.streamClient, ep, req, query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return obs.watch(ctx, pollingAlgo.Process(ctx, resp.Body)), ep.host, nil
+}
+
+// CloseQuery - terminates push query on server side.
+// Dropping connection is not enough: ksqlDB keeps
+// transient query alive until it is explicitly closed.
+// Request is sent to the node, that runs the query
+func (n *Transport) CloseQuery(
+	ctx context.Context,
+	host string,
+	queryID string) error {
+
+	q, _ := jsoniter.Marshal(struct {
+		QueryID string `json:"queryId"`
+	}{
+		QueryID: queryID,
+	})
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		host+consts.CloseQueryRoute,
+		bytes.NewReader(q),
+	)
+	if err != nil {
+		return fmt.Errorf("error while formating req: %w", err)
+	}
+
+	req.Header.Set(
+		consts.ContentType,
+		consts.HeaderJSON,
+	)
+
+	if err = n.authenticate(req); err != nil {
+		return err
+	}
+
+	resp, obs, err := n.do(n.streamClient, nil, req, "")
+	if err != nil {
+		return fmt.Errorf("query %s termination is not confirmed: %w", queryID, err)
+	}
+	defer resp.Body.Close()
+
+	obs.finish(shared.Outcome{})
+
+	return nil
+}
+
+// responseError - decodes ksqlDB error body of unsuccessful
+// response. Bodies of proxies and load balancers are
+// kept as message with http status only
+func responseError(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("cannot read error response: %w", err)
+	}
+
 	var (
-		resp *http.Response
+		msg dao.ErrorMessage
 	)
 
-	cli := http.DefaultClient
+	if err = jsoniter.Unmarshal(body, &msg); err != nil || msg.ErrorCode == 0 {
+		message := strings.TrimSpace(string(body))
+		if len(message) == 0 {
+			message = http.StatusText(resp.StatusCode)
+		}
 
-	if resp, err = cli.Do(req); err != nil {
-		return nil, fmt.Errorf("error while performing req: %w", err)
+		return &libErrors.KsqlError{
+			StatusCode: resp.StatusCode,
+			Message:    message,
+		}
 	}
 
-	return pollingAlgo.Process(resp.Body), nil
+	return msg.Err(resp.StatusCode)
 }
 
 type (
@@ -136,9 +450,12 @@ type (
 // Process - performs fast and ordinary http request
 // it can be used for show, describe, drop, create, insert
 func (sp ShortPolling) Process(
+	ctx context.Context,
 	payload io.ReadCloser,
 ) <-chan []byte {
 
+	// single message is buffered,
+	// so sender never waits for reader
 	ch := make(chan []byte, 1)
 
 	go func() {
@@ -160,14 +477,20 @@ type (
 )
 
 // Process - performs long-living requests. Mostly SELECT or SELECT with EMIT.
-// Channel is closed only on receiving EOF from KSQL-Client
+// Channel is closed on receiving EOF from KSQL-Client or when context is done.
+// Broken response is reported with final error frame
 func (lp LongPolling) Process(
-	payload io.ReadCloser) <-chan []byte {
+	ctx context.Context,
+	payload io.ReadCloser,
+) <-chan []byte {
 
 	ch := make(chan []byte)
 
 	go func() {
-		scanner := bufio.NewScanner(payload)
+		defer payload.Close()
+		defer close(ch)
+
+		scanner := newFrameScanner(payload)
 
 		for scanner.Scan() {
 			line := scanner.Text()
@@ -176,13 +499,126 @@ func (lp LongPolling) Process(
 				continue
 			}
 
-			ch <- []byte(line)
+			if !send(ctx, ch, []byte(line)) {
+				return
+			}
 		}
 
 		if err := scanner.Err(); err != nil {
-			return
+			send(ctx, ch, brokenFrame(err))
 		}
 	}()
 
 	return ch
 }
+
+type (
+	Delimited struct{}
+)
+
+const (
+	// maxFrameSize - upper bound for single delimited frame.
+	// Rows with huge nested collections exceed default scanner buffer
+	maxFrameSize = 4 * 1024 * 1024
+)
+
+// Process - performs http/2 delimited requests. Every non-empty line
+// is a standalone json document: header, row or error.
+// Channel is closed on receiving EOF or when context is done.
+// Broken connection, truncated body or oversize frame
+// are reported with final error frame
+func (d Delimited) Process(
+	ctx context.Context,
+	payload io.ReadCloser,
+) <-chan []byte {
+
+	ch := make(chan []byte)
+
+	go func() {
+		defer payload.Close()
+		defer close(ch)
+
+		scanner := newFrameScanner(payload)
+		scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxFrameSize)
+
+		for scanner.Scan() {
+			line := scanner.Bytes()
+
+			if len(line) == 0 {
+				continue
+			}
+
+			frame := make([]byte, len(line))
+			copy(frame, line)
+
+			if !send(ctx, ch, frame) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			send(ctx, ch, brokenFrame(err))
+		}
+	}()
+
+	return ch
+}
+
+// frameReader - remembers read error of response, so
+// unterminated tail of broken response isn't taken for a frame
+type frameReader struct {
+	io.Reader
+	err error
+}
+
+// newFrameScanner - splits response into lines, broken
+// response is reported with scanner error
+func newFrameScanner(payload io.Reader) *bufio.Scanner {
+	reader := &frameReader{
+		Reader: payload,
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Split(reader.split)
+
+	return scanner
+}
+
+// Read - implements io.Reader
+func (fr *frameReader) Read(p []byte) (int, error) {
+	n, err := fr.Reader.Read(p)
+	if err != nil && err != io.EOF {
+		fr.err = err
+	}
+	return n, err
+}
+
+// split - returns terminated lines. Unterminated tail
+// is a frame only if response is finished with EOF
+func (fr *frameReader) split(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && fr.err != nil && bytes.IndexByte(data, '\n') < 0 {
+		return 0, nil, fr.err
+	}
+	return bufio.ScanLines(data, atEOF)
+}
+
+// brokenFrame - error frame, that tells reader, that
+// response was not read till the end, so it isn't complete
+func brokenFrame(err error) []byte {
+	frame, _ := jsoniter.Marshal(dao.ErrorMessage{
+		Type:    dao.BrokenStreamType,
+		Message: err.Error(),
+	})
+	return frame
+}
+
+// send - passes frame to reader. Returns false,
+// if context is done and reader is gone
+func send(ctx context.Context, ch chan<- []byte, frame []byte) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case ch <- frame:
+		return true
+	}
+}
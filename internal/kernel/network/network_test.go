@@ -0,0 +1,207 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"github.com/gulfstream-h/ksql/consts"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dao"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newServer - fake ksqlDB node, which serves
+// handler over plain http and h2c
+func newServer(t *testing.T, handler http.Handler) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Config.Protocols = new(http.Protocols)
+	server.Config.Protocols.SetHTTP1(true)
+	server.Config.Protocols.SetUnencryptedHTTP2(true)
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func Test_ResponseError(t *testing.T) {
+	testcases := []struct {
+		name     string
+		status   int
+		body     string
+		expected *libErrors.KsqlError
+		sentinel error
+	}{
+		{
+			name:   "Statement error message",
+			status: http.StatusBadRequest,
+			body:   `{"@type":"statement_error","error_code":40001,"message":"Stream ORDERS does not exist.","statementText":"DROP STREAM ORDERS;"}`,
+			expected: &libErrors.KsqlError{
+				StatusCode: http.StatusBadRequest,
+				Code:       libErrors.CodeBadStatement,
+				Message:    "Stream ORDERS does not exist.",
+				Statement:  "DROP STREAM ORDERS;",
+			},
+			sentinel: libErrors.ErrNotFound,
+		},
+		{
+			name:   "Proxy text body",
+			status: http.StatusBadGateway,
+			body:   " upstream connect error \n",
+			expected: &libErrors.KsqlError{
+				StatusCode: http.StatusBadGateway,
+				Message:    "upstream connect error",
+			},
+			sentinel: libErrors.ErrServerUnavailable,
+		},
+		{
+			name:   "Empty body",
+			status: http.StatusUnauthorized,
+			expected: &libErrors.KsqlError{
+				StatusCode: http.StatusUnauthorized,
+				Message:    "Unauthorized",
+			},
+			sentinel: libErrors.ErrUnauthorized,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := responseError(&http.Response{
+				StatusCode: tc.status,
+				Body:       io.NopCloser(strings.NewReader(tc.body)),
+			})
+
+			var (
+				ksqlErr *libErrors.KsqlError
+			)
+
+			assert.True(t, errors.As(err, &ksqlErr))
+			assert.Equal(t, tc.expected, ksqlErr)
+			assert.ErrorIs(t, err, tc.sentinel)
+		})
+	}
+}
+
+func Test_PerformQueryStream(t *testing.T) {
+	const (
+		header = `{"queryId":"query_1","columnNames":["ID","NAME"],"columnTypes":["INTEGER","STRING"]}`
+	)
+
+	testcases := []struct {
+		name       string
+		body       string
+		wantFrames []string
+		wantBroken string
+	}{
+		{
+			name:       "Header and rows",
+			body:       header + "\n[1,\"first\"]\n\n[2,\"second\"]\n",
+			wantFrames: []string{header, `[1,"first"]`, `[2,"second"]`},
+		},
+		{
+			name:       "Unterminated last row",
+			body:       header + "\n[1,\"first\"]",
+			wantFrames: []string{header, `[1,"first"]`},
+		},
+		{
+			name: "Error frame",
+			body: header + "\n[1,\"first\"]\n" + `{"@type":"generic_error","error_code":50000,"message":"Query failed"}` + "\n",
+			wantFrames: []string{
+				header,
+				`[1,"first"]`,
+				`{"@type":"generic_error","error_code":50000,"message":"Query failed"}`,
+			},
+		},
+		{
+			name:       "Oversize frame",
+			body:       header + "\n[1,\"first\"]\n[2,\"" + strings.Repeat("x", maxFrameSize) + "\"]\n",
+			wantFrames: []string{header, `[1,"first"]`},
+			wantBroken: "token too long",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				requests = make(chan *http.Request, 1)
+			)
+
+			server := newServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests <- r
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(tc.body))
+			}))
+
+			transport := New(Settings{
+				Hosts:   []string{server.URL},
+				Timeout: time.Second,
+			})
+
+			frames, host, err := transport.PerformQueryStream(
+				context.Background(),
+				"SELECT ID, NAME FROM USERS;",
+				Properties{},
+				Delimited{},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, server.URL, host)
+
+			req := <-requests
+			assert.Equal(t, 2, req.ProtoMajor)
+			assert.Equal(t, consts.QueryStreamRoute, req.URL.Path)
+			assert.Equal(t, consts.HeaderDelimited, req.Header.Get(consts.Accept))
+
+			var (
+				received []string
+				broken   error
+			)
+
+			for frame := range frames {
+				var (
+					msg dao.ErrorMessage
+				)
+
+				if jsoniter.Unmarshal(frame, &msg) == nil && msg.Broken() {
+					broken = msg.BrokenErr()
+					continue
+				}
+
+				received = append(received, string(frame))
+			}
+
+			assert.Equal(t, tc.wantFrames, received)
+
+			if len(tc.wantBroken) == 0 {
+				assert.NoError(t, broken)
+				return
+			}
+
+			assert.ErrorIs(t, broken, libErrors.ErrBrokenStream)
+			assert.ErrorContains(t, broken, tc.wantBroken)
+		})
+	}
+}
+
+func Test_PerformQueryStreamRejected(t *testing.T) {
+	server := newServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"@type":"statement_error","error_code":40001,"message":"line 1:1: Syntax Error"}`))
+	}))
+
+	transport := New(Settings{
+		Hosts:   []string{server.URL},
+		Timeout: time.Second,
+	})
+
+	frames, _, err := transport.PerformQueryStream(context.Background(), "SELEC 1;", Properties{}, Delimited{})
+	assert.Nil(t, frames)
+	assert.ErrorIs(t, err, libErrors.ErrBadStatement)
+}
@@ -0,0 +1,43 @@
+package network
+
+import (
+	"maps"
+)
+
+// Properties - request-scoped settings of ksqlDB statement
+type Properties struct {
+	Streams   map[string]any // streamsProperties, e.g. auto.offset.reset
+	Variables map[string]any // sessionVariables, substituted into ${name}
+	Request   map[string]any // requestProperties, e.g. continuation token of push query
+
+	// CommandSequence - server awaits execution of command with
+	// such sequence number before running statement. Zero is omitted.
+	// It's sent to /ksql only: /query-stream doesn't accept it
+	CommandSequence int64
+}
+
+// merge - overrides default properties with request ones
+func (p Properties) merge(request Properties) Properties {
+	return Properties{
+		Streams:         mergeMaps(p.Streams, request.Streams),
+		Variables:       mergeMaps(p.Variables, request.Variables),
+		Request:         mergeMaps(p.Request, request.Request),
+		CommandSequence: request.CommandSequence,
+	}
+}
+
+// mergeMaps - returns union of maps, second one takes precedence
+func mergeMaps(defaults, request map[string]any) map[string]any {
+	if len(request) == 0 {
+		return defaults
+	}
+
+	if len(defaults) == 0 {
+		return request
+	}
+
+	merged := maps.Clone(defaults)
+	maps.Copy(merged, request)
+
+	return merged
+}
@@ -0,0 +1,85 @@
+package network
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_PropertiesMerge(t *testing.T) {
+	testcases := []struct {
+		name     string
+		defaults Properties
+		request  Properties
+		expected Properties
+	}{
+		{
+			name: "Empty request keeps defaults",
+			defaults: Properties{
+				Streams:   map[string]any{"auto.offset.reset": "latest"},
+				Variables: map[string]any{"topic": "orders"},
+			},
+			request: Properties{},
+			expected: Properties{
+				Streams:   map[string]any{"auto.offset.reset": "latest"},
+				Variables: map[string]any{"topic": "orders"},
+			},
+		},
+		{
+			name:     "Empty defaults take request",
+			defaults: Properties{},
+			request: Properties{
+				Streams: map[string]any{"auto.offset.reset": "earliest"},
+				Request: map[string]any{"ksql.query.push.v2.continuation.token": "token_1"},
+			},
+			expected: Properties{
+				Streams: map[string]any{"auto.offset.reset": "earliest"},
+				Request: map[string]any{"ksql.query.push.v2.continuation.token": "token_1"},
+			},
+		},
+		{
+			name: "Request overrides defaults with the same key",
+			defaults: Properties{
+				Streams: map[string]any{
+					"auto.offset.reset":                  "latest",
+					"ksql.query.pull.table.scan.enabled": true,
+				},
+				Variables: map[string]any{"topic": "orders", "format": "JSON"},
+			},
+			request: Properties{
+				Streams:   map[string]any{"auto.offset.reset": "earliest"},
+				Variables: map[string]any{"topic": "payments"},
+			},
+			expected: Properties{
+				Streams: map[string]any{
+					"auto.offset.reset":                  "earliest",
+					"ksql.query.pull.table.scan.enabled": true,
+				},
+				Variables: map[string]any{"topic": "payments", "format": "JSON"},
+			},
+		},
+		{
+			name:     "Command sequence is taken from request",
+			defaults: Properties{CommandSequence: 5},
+			request:  Properties{CommandSequence: 7},
+			expected: Properties{CommandSequence: 7},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.defaults.merge(tc.request))
+		})
+	}
+}
+
+func Test_PropertiesMergeKeepsDefaults(t *testing.T) {
+	defaults := Properties{
+		Streams: map[string]any{"auto.offset.reset": "latest"},
+	}
+
+	defaults.merge(Properties{
+		Streams: map[string]any{"auto.offset.reset": "earliest"},
+	})
+
+	assert.Equal(t, map[string]any{"auto.offset.reset": "latest"}, defaults.Streams)
+}
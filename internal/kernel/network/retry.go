@@ -0,0 +1,117 @@
+package network
+
+import (
+	"context"
+	"errors"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// RetryPolicy - describes how failed requests are repeated.
+// Zero value performs single attempt
+type RetryPolicy struct {
+	MaxAttempts    int           // overall attempts count, including first one
+	InitialBackoff time.Duration // pause before second attempt
+	MaxBackoff     time.Duration // upper limit of growing pause
+
+	// StatusCodes - http statuses, that are considered as temporary
+	StatusCodes []int
+	// Retryable - overrides default decision
+	// based on status codes and connection errors
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy - survives short restarts of ksqlDB
+// instances during rolling upgrades
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		StatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// Do - performs attempt till it succeeds, fails with
+// permanent error or attempts are exhausted.
+// Last error is returned
+func (p RetryPolicy) Do(
+	ctx context.Context,
+	attempt func() error,
+) error {
+
+	var (
+		err error
+	)
+
+	for i := 0; ; i++ {
+		if err = attempt(); err == nil {
+			return nil
+		}
+
+		if i+1 >= p.MaxAttempts || !p.retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(p.Backoff(i))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable - checks if error is temporary
+func (p RetryPolicy) retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+
+	var (
+		ksqlErr *libErrors.KsqlError
+		urlErr  *url.Error
+	)
+
+	if errors.As(err, &ksqlErr) {
+		return slices.Contains(p.StatusCodes, ksqlErr.StatusCode)
+	}
+
+	// server is not reachable or dropped connection
+	return errors.As(err, &urlErr)
+}
+
+// maxBackoffShift - limits exponent of
+// backoff growth, so pause doesn't overflow
+const maxBackoffShift = 16
+
+// Backoff - exponentially growing pause with jitter before
+// attempt following the given one (zero based), so
+// clients don't hit restarted server simultaneously
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff << min(max(attempt, 0), maxBackoffShift)
+	if backoff <= 0 || (p.MaxBackoff > 0 && backoff > p.MaxBackoff) {
+		backoff = p.MaxBackoff
+	}
+
+	if backoff <= 0 {
+		return 0
+	}
+
+	return backoff/2 + rand.N(backoff/2+1)
+}
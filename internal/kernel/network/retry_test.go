@@ -0,0 +1,204 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func Test_Retryable(t *testing.T) {
+	var (
+		errTemporary = errors.New("temporary")
+		connErr      = &url.Error{Op: "Post", URL: "http://localhost:8088/ksql", Err: errors.New("connection refused")}
+	)
+
+	testcases := []struct {
+		name     string
+		policy   RetryPolicy
+		err      error
+		expected bool
+	}{
+		{
+			name:     "Connection error",
+			policy:   DefaultRetryPolicy(),
+			err:      connErr,
+			expected: true,
+		},
+		{
+			name:     "Listed status",
+			policy:   DefaultRetryPolicy(),
+			err:      fmt.Errorf("cannot perform: %w", &libErrors.KsqlError{StatusCode: http.StatusServiceUnavailable}),
+			expected: true,
+		},
+		{
+			name:   "Statement error",
+			policy: DefaultRetryPolicy(),
+			err:    &libErrors.KsqlError{StatusCode: http.StatusBadRequest, Code: libErrors.CodeBadStatement},
+		},
+		{
+			name:   "Status is not listed",
+			policy: RetryPolicy{},
+			err:    &libErrors.KsqlError{StatusCode: http.StatusServiceUnavailable},
+		},
+		{
+			name:   "Unknown error",
+			policy: DefaultRetryPolicy(),
+			err:    errors.New("unexpected"),
+		},
+		{
+			name:   "Cancelled context",
+			policy: DefaultRetryPolicy(),
+			err:    errors.Join(context.Canceled, connErr),
+		},
+		{
+			name:   "Expired context",
+			policy: RetryPolicy{Retryable: func(error) bool { return true }},
+			err:    context.DeadlineExceeded,
+		},
+		{
+			name: "Custom decision",
+			policy: RetryPolicy{Retryable: func(err error) bool {
+				return errors.Is(err, errTemporary)
+			}},
+			err:      errTemporary,
+			expected: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.policy.retryable(tc.err))
+		})
+	}
+}
+
+func Test_RetryDo(t *testing.T) {
+	var (
+		unavailable = &libErrors.KsqlError{StatusCode: http.StatusServiceUnavailable}
+		rejected    = &libErrors.KsqlError{StatusCode: http.StatusBadRequest, Code: libErrors.CodeBadStatement}
+		policy      = RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+			StatusCodes:    []int{http.StatusServiceUnavailable},
+		}
+	)
+
+	testcases := []struct {
+		name         string
+		policy       RetryPolicy
+		errs         []error
+		wantAttempts int
+		wantErr      error
+	}{
+		{
+			name:         "First attempt succeeds",
+			policy:       policy,
+			errs:         []error{nil},
+			wantAttempts: 1,
+		},
+		{
+			name:         "Temporary failure is repeated",
+			policy:       policy,
+			errs:         []error{unavailable, unavailable, nil},
+			wantAttempts: 3,
+		},
+		{
+			name:         "Attempts are exhausted",
+			policy:       policy,
+			errs:         []error{unavailable, unavailable, unavailable, nil},
+			wantAttempts: 3,
+			wantErr:      unavailable,
+		},
+		{
+			name:         "Permanent failure is not repeated",
+			policy:       policy,
+			errs:         []error{rejected, nil},
+			wantAttempts: 1,
+			wantErr:      rejected,
+		},
+		{
+			name:         "Zero policy performs single attempt",
+			policy:       RetryPolicy{},
+			errs:         []error{unavailable, nil},
+			wantAttempts: 1,
+			wantErr:      unavailable,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+
+			err := tc.policy.Do(context.Background(), func() error {
+				attempts++
+				return tc.errs[attempts-1]
+			})
+
+			assert.Equal(t, tc.wantAttempts, attempts)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func Test_Backoff(t *testing.T) {
+	testcases := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{
+			name:    "First attempt",
+			policy:  RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Minute},
+			attempt: 0,
+			min:     500 * time.Millisecond,
+			max:     time.Second,
+		},
+		{
+			name:    "Growing pause",
+			policy:  RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Minute},
+			attempt: 3,
+			min:     4 * time.Second,
+			max:     8 * time.Second,
+		},
+		{
+			name:    "Pause is limited",
+			policy:  RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second},
+			attempt: 10,
+			min:     2500 * time.Millisecond,
+			max:     5 * time.Second,
+		},
+		{
+			name:    "Unlimited pause doesn't overflow",
+			policy:  RetryPolicy{InitialBackoff: time.Second},
+			attempt: 100,
+			min:     (time.Second << maxBackoffShift) / 2,
+			max:     time.Second << maxBackoffShift,
+		},
+		{
+			name:    "Zero policy",
+			policy:  RetryPolicy{},
+			attempt: 5,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			backoff := tc.policy.Backoff(tc.attempt)
+			assert.GreaterOrEqual(t, backoff, tc.min)
+			assert.LessOrEqual(t, backoff, tc.max)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package dao
+
+import (
+	"encoding/json"
+	"fmt"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+)
+
+// BrokenStreamType - type of error frame, that is appended to
+// query stream by library, when response body cannot be read
+// till the end: dropped connection, truncated body or oversize frame
+const BrokenStreamType = "broken_stream"
+
+// ErrorMessage - KsqlErrorMessage and KsqlStatementErrorMessage
+// bodies. Same structure is used for error frames of query stream
+type ErrorMessage struct {
+	Type          string            `json:"@type"`
+	ErrorCode     int               `json:"error_code"`
+	Message       string            `json:"message"`
+	StatementText string            `json:"statementText"`
+	Entities      []json.RawMessage `json:"entities"`
+	StackTrace    []string          `json:"stackTrace"`
+}
+
+// Err - converts message to library error
+func (em ErrorMessage) Err(statusCode int) *libErrors.KsqlError {
+	return &libErrors.KsqlError{
+		StatusCode: statusCode,
+		Code:       em.ErrorCode,
+		Message:    em.Message,
+		Statement:  em.StatementText,
+		Entities:   em.Entities,
+		StackTrace: em.StackTrace,
+	}
+}
+
+// Broken - reports if message describes broken
+// response instead of server error
+func (em ErrorMessage) Broken() bool {
+	return em.Type == BrokenStreamType
+}
+
+// BrokenErr - converts broken response message to library error
+func (em ErrorMessage) BrokenErr() error {
+	return fmt.Errorf("%w: %s", libErrors.ErrBrokenStream, em.Message)
+}
@@ -0,0 +1,19 @@
+package dao
+
+// HealthCheck - /healthcheck response
+type HealthCheck struct {
+	IsHealthy bool `json:"isHealthy"`
+}
+
+// HostStatus - liveness of single node,
+// as it's seen by heartbeat of other nodes
+type HostStatus struct {
+	HostAlive          bool  `json:"hostAlive"`
+	LastStatusUpdateMs int64 `json:"lastStatusUpdateMs"`
+}
+
+// ClusterStatus - /clusterStatus response,
+// nodes are keyed by host:port
+type ClusterStatus struct {
+	ClusterStatus map[string]HostStatus `json:"clusterStatus"`
+}
@@ -17,3 +17,18 @@ type Row struct {
 type Columns struct {
 	Columns []any `json:"columns"`
 }
+
+// QueryStreamHeader - first frame of /query-stream
+// delimited response. Describes columns of following rows
+type QueryStreamHeader struct {
+	QueryID     string   `json:"queryId"`
+	ColumnNames []string `json:"columnNames"`
+	ColumnTypes []string `json:"columnTypes"`
+}
+
+// ContinuationToken - control frame of scalable push query.
+// Marks position of already sent rows, so query
+// can be resumed after it
+type ContinuationToken struct {
+	ContinuationToken string `json:"continuationToken"`
+}
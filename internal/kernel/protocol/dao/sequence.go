@@ -0,0 +1,7 @@
+package dao
+
+// CommandSequence - part of every command response,
+// that identifies its position in ksqlDB command topic
+type CommandSequence struct {
+	CommandSequenceNumber int64 `json:"commandSequenceNumber"`
+}
@@ -23,6 +23,37 @@ func ParseNetResponse[S any](
 		return streamDTO, fmt.Errorf("parse headers and values: %w", err)
 	}
 
+	return fillStruct[S](resultDict)
+}
+
+// ParseStreamResponse - parses /query-stream row
+// into defined fields of clients generic.
+// Column names and types are taken from the header frame
+func ParseStreamResponse[S any](
+	header dao.QueryStreamHeader,
+	columns []any,
+) (S, error) {
+	var (
+		streamDTO S
+	)
+
+	resultDict, err := ParseColumnsAndValues(header.ColumnNames, header.ColumnTypes, columns)
+	if err != nil {
+		return streamDTO, fmt.Errorf("parse columns and values: %w", err)
+	}
+
+	return fillStruct[S](resultDict)
+}
+
+// fillStruct - sets parsed values to
+// ksql tagged fields of clients generic
+func fillStruct[S any](
+	resultDict map[string]any,
+) (S, error) {
+	var (
+		streamDTO S
+	)
+
 	val, err := reflector.GetValue(&streamDTO)
 	if err != nil {
 		return streamDTO, fmt.Errorf("reflector: get value: %w", err)
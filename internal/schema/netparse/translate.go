@@ -56,6 +56,37 @@ func ParseHeadersAndValues(
 	return result, nil
 }
 
+// ParseColumnsAndValues - groups /query-stream header
+// column names with row values in coinciding pairs
+func ParseColumnsAndValues(
+	names []string,
+	types []string,
+	values []any,
+) (map[string]any, error) {
+
+	if len(names) != len(values) {
+		return nil, fmt.Errorf("columns and values count mismatch")
+	}
+
+	result := make(map[string]any, len(names))
+
+	for i, name := range names {
+		if i < len(types) && types[i] == "BYTES" && values[i] != nil {
+			castedValue, ok := values[i].(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string alias for BYTES type, got %T", values[i])
+			}
+
+			result[name] = []byte(castedValue)
+			continue
+		}
+
+		result[name] = values[i]
+	}
+
+	return result, nil
+}
+
 // NormalizeValue - defines the real type of
 // unmarshalled ksql response interface field
 // and generates reflect value, that can be set
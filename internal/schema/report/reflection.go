@@ -9,10 +9,11 @@ import (
 // ReflectionReportRemote - compares in-cache
 // describe structure with collected fields
 func ReflectionReportRemote(
+	cache *static.Cache,
 	remote string,
 	parsed map[string]schema.SearchField,
 ) error {
-	remoteRelation, err := static.FindRelationFields(remote)
+	remoteRelation, err := cache.FindRelationFields(remote)
 	if err != nil {
 		return fmt.Errorf("cannot find remote relation %s: %w", remote, err)
 	}
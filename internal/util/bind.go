@@ -0,0 +1,84 @@
+package util
+
+import (
+	"fmt"
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"strings"
+)
+
+const (
+	// Placeholder - marks position of bound argument in statement
+	Placeholder = '?'
+)
+
+// Bind - substitutes placeholders of statement with serialized
+// arguments. Question marks inside string literals and
+// quoted identifiers are kept as is. Every argument becomes
+// single escaped literal, so it cannot alter statement
+func Bind(query string, args ...any) (string, error) {
+	var (
+		builder strings.Builder
+		runes   = []rune(query)
+		next    = 0
+	)
+
+	builder.Grow(len(query))
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch r {
+		case '\'', '`', '"':
+			// copy quoted part till closing quote,
+			// doubled quote is an escaped one
+			builder.WriteRune(r)
+			for i++; i < len(runes); i++ {
+				builder.WriteRune(runes[i])
+				if runes[i] != r {
+					continue
+				}
+				if i+1 < len(runes) && runes[i+1] == r {
+					i++
+					builder.WriteRune(runes[i])
+					continue
+				}
+				break
+			}
+		case Placeholder:
+			if next >= len(args) {
+				return "", fmt.Errorf("%w: %d arguments", libErrors.ErrArgumentsMismatch, len(args))
+			}
+
+			literal, err := literalOf(args[next])
+			if err != nil {
+				return "", fmt.Errorf("argument %d: %w", next+1, err)
+			}
+
+			builder.WriteString(literal)
+			next++
+		default:
+			builder.WriteRune(r)
+		}
+	}
+
+	if next != len(args) {
+		return "", fmt.Errorf("%w: %d placeholders, %d arguments", libErrors.ErrArgumentsMismatch, next, len(args))
+	}
+
+	return builder.String(), nil
+}
+
+// literalOf - serializes bound argument. Builder
+// expressions are not values, so they cannot be bound
+func literalOf(arg any) (string, error) {
+	if _, ok := arg.(expression); ok && !IsNil(arg) {
+		return "", fmt.Errorf("unsupported argument type %T", arg)
+	}
+
+	literal := Serialize(arg)
+	if len(literal) == 0 {
+		return "", fmt.Errorf("unsupported argument type %T", arg)
+	}
+
+	return literal, nil
+}
@@ -0,0 +1,71 @@
+package util
+
+import (
+	libErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_Bind(t *testing.T) {
+	testcases := []struct {
+		name      string
+		query     string
+		args      []any
+		expected  string
+		expectErr error
+	}{
+		{
+			name:     "Values are escaped",
+			query:    "SELECT * FROM users WHERE NAME = ? AND AGE > ?;",
+			args:     []any{"x' OR '1'='1", 18},
+			expected: "SELECT * FROM users WHERE NAME = 'x'' OR ''1''=''1' AND AGE > 18;",
+		},
+		{
+			name:     "Stringer is quoted",
+			query:    "SELECT * FROM users WHERE NAME = ?;",
+			args:     []any{quotedName{}},
+			expected: "SELECT * FROM users WHERE NAME = 'O''Brien';",
+		},
+		{
+			name:     "Placeholders in literals are kept",
+			query:    "SELECT * FROM users WHERE NOTE = 'why?' AND `ID?` = ?;",
+			args:     []any{1},
+			expected: "SELECT * FROM users WHERE NOTE = 'why?' AND `ID?` = 1;",
+		},
+		{
+			name:     "Nil is null",
+			query:    "INSERT INTO users (NAME) VALUES (?);",
+			args:     []any{nil},
+			expected: "INSERT INTO users (NAME) VALUES (NULL);",
+		},
+		{
+			name:      "Missing argument",
+			query:     "SELECT * FROM users WHERE ID = ? OR ID = ?;",
+			args:      []any{1},
+			expectErr: libErrors.ErrArgumentsMismatch,
+		},
+		{
+			name:      "Extra argument",
+			query:     "SELECT * FROM users;",
+			args:      []any{1},
+			expectErr: libErrors.ErrArgumentsMismatch,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := Bind(tc.query, tc.args...)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, query)
+		})
+	}
+}
+
+func Test_BindExpression(t *testing.T) {
+	_, err := Bind("SELECT * FROM users WHERE NAME = ?;", rawExpression{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package util
+
+import (
+	"log/slog"
+	"strings"
+	"unicode"
+)
+
+// RedactStatement - replaces string and numeric literals
+// of ksql statement with placeholders, so statement can
+// be logged without leaking inserted or filtered values
+func RedactStatement(query string) string {
+	var (
+		builder strings.Builder
+		runes   = []rune(query)
+	)
+
+	builder.Grow(len(query))
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == '\'':
+			// quotes inside literal are escaped by doubling
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			builder.WriteString("'?'")
+		case unicode.IsDigit(r) && (i == 0 || !isIdentifierRune(runes[i-1])):
+			for i+1 < len(runes) && (unicode.IsDigit(runes[i+1]) || runes[i+1] == '.') {
+				i++
+			}
+			builder.WriteRune('?')
+		default:
+			builder.WriteRune(r)
+		}
+	}
+
+	return builder.String()
+}
+
+// isIdentifierRune - checks if rune can be part of identifier
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '`'
+}
+
+// Statement - log value, which redacts
+// statement only when record is emitted
+type Statement string
+
+// LogValue - implements slog.LogValuer
+func (s Statement) LogValue() slog.Value {
+	return slog.StringValue(RedactStatement(string(s)))
+}
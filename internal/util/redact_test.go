@@ -0,0 +1,58 @@
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"testing"
+)
+
+func Test_RedactStatement(t *testing.T) {
+	testcases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "Without literals",
+			query:    "SHOW STREAMS;",
+			expected: "SHOW STREAMS;",
+		},
+		{
+			name:     "String literal",
+			query:    "INSERT INTO USERS (ID, NAME) VALUES (1, 'John');",
+			expected: "INSERT INTO USERS (ID, NAME) VALUES (?, '?');",
+		},
+		{
+			name:     "Escaped quote inside literal",
+			query:    "SELECT * FROM USERS WHERE NAME = 'O''Brien' AND AGE > 18;",
+			expected: "SELECT * FROM USERS WHERE NAME = '?' AND AGE > ?;",
+		},
+		{
+			name:     "Decimal literal",
+			query:    "SELECT * FROM ORDERS WHERE PRICE >= 10.5 LIMIT 3;",
+			expected: "SELECT * FROM ORDERS WHERE PRICE >= ? LIMIT ?;",
+		},
+		{
+			name:     "Digits inside identifiers",
+			query:    "SELECT COL1, `2FA` FROM TABLE_2 WHERE COL1 = 7;",
+			expected: "SELECT COL1, `2FA` FROM TABLE_2 WHERE COL1 = ?;",
+		},
+		{
+			name:     "Unterminated literal",
+			query:    "SELECT * FROM USERS WHERE NAME = 'John",
+			expected: "SELECT * FROM USERS WHERE NAME = '?'",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, RedactStatement(tc.query))
+		})
+	}
+}
+
+func Test_StatementLogValue(t *testing.T) {
+	value := Statement("SELECT * FROM USERS WHERE NAME = 'John';").LogValue()
+	assert.Equal(t, slog.KindString, value.Kind())
+	assert.Equal(t, "SELECT * FROM USERS WHERE NAME = '?';", value.String())
+}
@@ -18,7 +18,7 @@ func FormatSlice(slice any) (string, error) {
 		v := val.Index(i).Interface()
 		switch x := v.(type) {
 		case string:
-			parts = append(parts, fmt.Sprintf("'%s'", x))
+			parts = append(parts, Quote(x))
 		case int, int64, float64:
 			parts = append(parts, fmt.Sprintf("%v", x))
 		case bool:
@@ -38,15 +38,45 @@ func FormatSlice(slice any) (string, error) {
 	return "(" + strings.Join(parts, ", ") + ")", nil
 }
 
-// Serialize - checks interface type and serialize it for kafka
+// Quote - wraps string into ksql literal. Embedded
+// quotes are escaped by doubling, so value
+// cannot terminate literal and alter statement
+func Quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// expression - contract of query builder expressions,
+// e.g. fields, which are written into statement as is
+type expression interface {
+	Expression() (string, error)
+}
+
+// Serialize - checks interface type and serialize it for kafka.
+// Only builder expressions are written as is, other values,
+// including fmt.Stringer output, become escaped literals
 func Serialize(val any) string {
+	if IsNil(val) {
+		return "NULL"
+	}
+
 	switch v := val.(type) {
 	case []byte:
-		return "'" + string(v) + "'"
+		return Quote(string(v))
 	case string:
-		return "'" + v + "'"
+		return Quote(v)
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case expression:
+		expr, err := v.Expression()
+		if err != nil {
+			return ""
+		}
+		return expr
 	case fmt.Stringer:
-		return v.String()
+		return Quote(v.String())
 	case float32, float64:
 		return fmt.Sprintf("%v", v)
 	case int, int8, int16, int32, int64:
@@ -56,7 +86,7 @@ func Serialize(val any) string {
 	case []string:
 		parts := make([]string, len(v))
 		for i, s := range v {
-			parts[i] = "'" + s + "'"
+			parts[i] = Quote(s)
 		}
 		return "ARRAY[" + strings.Join(parts, ", ") + "]"
 	case []int:
@@ -84,35 +114,32 @@ func Serialize(val any) string {
 	case map[string]string:
 		parts := make([]string, 0, len(v))
 		for k, s := range v {
-			parts = append(parts, fmt.Sprintf("'%s' := '%s'", k, s))
+			parts = append(parts, Quote(k)+" := "+Quote(s))
 		}
 		return "MAP(" + strings.Join(parts, ", ") + ")"
 	case map[string]int:
 		parts := make([]string, 0, len(v))
 		for k, n := range v {
-			parts = append(parts, fmt.Sprintf("'%s' := %d", k, n))
+			parts = append(parts, fmt.Sprintf("%s := %d", Quote(k), n))
 		}
 		return "MAP(" + strings.Join(parts, ", ") + ")"
 	case map[string]float64:
 		parts := make([]string, 0, len(v))
 		for k, n := range v {
-			parts = append(parts, fmt.Sprintf("'%s' := %v", k, n))
+			parts = append(parts, fmt.Sprintf("%s := %v", Quote(k), n))
 		}
 		return "MAP(" + strings.Join(parts, ", ") + ")"
 	case map[string]bool:
 		parts := make([]string, 0, len(v))
 		for k, b := range v {
 			if b {
-				parts = append(parts, fmt.Sprintf("'%s' := TRUE", k))
+				parts = append(parts, Quote(k)+" := TRUE")
 			} else {
-				parts = append(parts, fmt.Sprintf("'%s' := FALSE", k))
+				parts = append(parts, Quote(k)+" := FALSE")
 			}
 		}
 		return "MAP(" + strings.Join(parts, ", ") + ")"
 	default:
-		if IsNil(v) {
-			return "NULL"
-		}
 		return ""
 	}
 }
@@ -0,0 +1,88 @@
+package util
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type (
+	// quotedName - value with quote in its string representation
+	quotedName struct{}
+	// rawExpression - builder expression, which is written as is
+	rawExpression struct{}
+)
+
+func (quotedName) String() string { return "O'Brien" }
+
+func (rawExpression) String() string { return "ignored" }
+
+func (rawExpression) Expression() (string, error) { return "UCASE(NAME)", nil }
+
+func Test_Serialize(t *testing.T) {
+	var (
+		nilStringer *quotedName
+	)
+
+	testcases := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{
+			name:     "String with quote",
+			value:    "it's",
+			expected: "'it''s'",
+		},
+		{
+			name:     "Bytes",
+			value:    []byte("abc"),
+			expected: "'abc'",
+		},
+		{
+			name:     "Integer",
+			value:    42,
+			expected: "42",
+		},
+		{
+			name:     "Boolean",
+			value:    false,
+			expected: "FALSE",
+		},
+		{
+			name:     "Stringer with quote",
+			value:    quotedName{},
+			expected: "'O''Brien'",
+		},
+		{
+			name:     "Nil stringer",
+			value:    nilStringer,
+			expected: "NULL",
+		},
+		{
+			name:     "Builder expression",
+			value:    rawExpression{},
+			expected: "UCASE(NAME)",
+		},
+		{
+			name:     "Strings array",
+			value:    []string{"a", "b'c"},
+			expected: "ARRAY['a', 'b''c']",
+		},
+		{
+			name:     "Nil",
+			value:    nil,
+			expected: "NULL",
+		},
+		{
+			name:     "Unsupported type",
+			value:    struct{}{},
+			expected: "",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Serialize(tc.value))
+		})
+	}
+}
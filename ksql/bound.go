@@ -0,0 +1,46 @@
+package ksql
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gulfstream-h/ksql/internal/util"
+)
+
+// boundExp - conditional expression written by hand,
+// which values are bound to ? placeholders
+type boundExp struct {
+	template string
+	args     []any
+}
+
+// Bind - creates conditional expression from template with ?
+// placeholders. Arguments are serialized into escaped literals,
+// so application data is never concatenated into statement.
+// Template columns are not collected by reflection linter
+func Bind(template string, args ...any) Conditional {
+	return &boundExp{template: template, args: args}
+}
+
+// Expression - substitutes placeholders with arguments
+func (b *boundExp) Expression() (string, error) {
+	if len(b.template) == 0 {
+		return "", errors.New("bound expression template cannot be empty")
+	}
+
+	expression, err := util.Bind(b.template, b.args...)
+	if err != nil {
+		return "", fmt.Errorf("bind arguments: %w", err)
+	}
+
+	return expression, nil
+}
+
+// Left - template fields are unknown
+func (b *boundExp) Left() []Field {
+	return nil
+}
+
+// Right - returns bound arguments
+func (b *boundExp) Right() []any {
+	return b.args
+}
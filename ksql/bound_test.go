@@ -0,0 +1,88 @@
+package ksql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundExpression(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		args      []any
+		wantExpr  string
+		expectErr bool
+	}{
+		{
+			name:     "Single string",
+			template: "name = ?",
+			args:     []any{"foo"},
+			wantExpr: "name = 'foo'",
+		},
+		{
+			name:     "Escaped string",
+			template: "name = ?",
+			args:     []any{"O'Brien"},
+			wantExpr: "name = 'O''Brien'",
+		},
+		{
+			name:     "Several arguments",
+			template: "id > ? AND active = ? AND score < ?",
+			args:     []any{10, true, 2.5},
+			wantExpr: "id > 10 AND active = TRUE AND score < 2.5",
+		},
+		{
+			name:     "Placeholder inside literal is kept",
+			template: "name = '?' AND id = ?",
+			args:     []any{1},
+			wantExpr: "name = '?' AND id = 1",
+		},
+		{
+			name:     "Nil argument",
+			template: "name = ?",
+			args:     []any{nil},
+			wantExpr: "name = NULL",
+		},
+		{
+			name:      "Missing argument",
+			template:  "id = ? AND name = ?",
+			args:      []any{1},
+			expectErr: true,
+		},
+		{
+			name:      "Extra argument",
+			template:  "id = ?",
+			args:      []any{1, 2},
+			expectErr: true,
+		},
+		{
+			name:      "Empty template",
+			template:  "",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp := Bind(tt.template, tt.args...)
+			expr, err := exp.Expression()
+			assert.Equal(t, tt.expectErr, err != nil)
+			if !tt.expectErr {
+				assert.Equal(t, tt.wantExpr, expr)
+				assert.Nil(t, exp.Left())
+				assert.Equal(t, tt.args, exp.Right())
+			}
+		})
+	}
+}
+
+func TestBoundExpressionInSelect(t *testing.T) {
+	expr, err := Select(F("name")).
+		From(Schema("users", STREAM)).
+		Where(Bind("name = ?", "x' OR '1'='1")).
+		Expression()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT name FROM users WHERE name = 'x'' OR ''1''=''1';", expr)
+}
@@ -23,6 +23,10 @@ type (
 	Comparable interface {
 		Equal(val any) Conditional
 		NotEqual(val any) Conditional
+		IsDistinctFrom(val any) Conditional
+		IsNotDistinctFrom(val any) Conditional
+		Like(pattern any) Conditional
+		NotLike(pattern any) Conditional
 	}
 
 	Ordered interface {
@@ -30,6 +34,8 @@ type (
 		Less(val any) Conditional
 		GreaterEq(val any) Conditional
 		LessEq(val any) Conditional
+		Between(from, to any) Conditional
+		NotBetween(from, to any) Conditional
 		Asc() OrderedExpression
 		Desc() OrderedExpression
 	}
@@ -37,6 +43,8 @@ type (
 	Nullable interface {
 		IsNull() Conditional
 		IsNotNull() Conditional
+		IsTrue() Conditional
+		IsFalse() Conditional
 	}
 
 	ComparableArray interface {
@@ -83,6 +91,18 @@ const (
 	in
 	// notIn is used to check if a value is not in a set of values
 	notIn
+	// like checks if a string matches a pattern
+	like
+	// notLike checks if a string doesn't match a pattern
+	notLike
+	// between checks if a value is in inclusive range
+	between
+	// notBetween checks if a value is out of inclusive range
+	notBetween
+	// isDistinctFrom checks if values differ, treating nulls as comparable
+	isDistinctFrom
+	// isNotDistinctFrom checks if values are equal, treating nulls as comparable
+	isNotDistinctFrom
 )
 
 // booleanExp - represents a boolean expression with a left field, right value, and operation
@@ -103,6 +123,7 @@ func (b *booleanExp) Expression() (string, error) {
 		operation   string
 		ordered     bool
 		iterable    bool
+		pattern     bool
 		rightString string
 	)
 
@@ -143,14 +164,34 @@ func (b *booleanExp) Expression() (string, error) {
 	case notIn:
 		operation = "NOT IN"
 		iterable = true
+	case like:
+		operation = "LIKE"
+		pattern = true
+	case notLike:
+		operation = "NOT LIKE"
+		pattern = true
+	case between, notBetween:
+		return b.rangeExpression(expression)
+	case isDistinctFrom:
+		return b.distinctExpression(expression, "IS DISTINCT FROM")
+	case isNotDistinctFrom:
+		return b.distinctExpression(expression, "IS NOT DISTINCT FROM")
 	default:
 		return "", fmt.Errorf("unsupported operation: %d", b.operation)
 	}
 
-	if ordered && !util.IsOrdered(b.right) {
+	// fields and expressions over them are compared on server side
+	_, isField := b.right.(Field)
+	if ordered && !isField && !util.IsOrdered(b.right) {
 		return "", fmt.Errorf("operation requeres right expression to be ordered: %v", b.right)
 	}
 
+	if pattern {
+		if _, isString := b.right.(string); !isString && !isField {
+			return "", fmt.Errorf("operation requires string pattern: %v", b.right)
+		}
+	}
+
 	if iterable {
 		if !util.IsIterable(b.right) {
 			return "", fmt.Errorf("operation requires right expression to be iterable: %v", b.right)
@@ -172,7 +213,7 @@ func (b *booleanExp) Expression() (string, error) {
 	case float32, float64:
 		rightString = fmt.Sprintf("%v", v)
 	case string:
-		rightString = fmt.Sprintf("'%s'", v)
+		rightString = util.Quote(v)
 	case bool:
 		rightString = strings.ToUpper(strconv.FormatBool(v))
 	case Field:
@@ -200,12 +241,71 @@ func (b *booleanExp) Expression() (string, error) {
 	return fmt.Sprintf("%s %s %s", expression, operation, rightString), nil
 }
 
+// rangeExpression - builds BETWEEN expression from pair of bounds
+func (b *booleanExp) rangeExpression(expression string) (string, error) {
+	bounds, ok := b.right.([]any)
+	if !ok || len(bounds) != 2 {
+		return "", fmt.Errorf("range requires lower and upper bounds: %v", b.right)
+	}
+
+	operation := "BETWEEN"
+	if b.operation == notBetween {
+		operation = "NOT BETWEEN"
+	}
+
+	rendered := make([]string, 0, len(bounds))
+	for _, bound := range bounds {
+		if _, isField := bound.(Field); !isField && !util.IsOrdered(bound) {
+			return "", fmt.Errorf("range bound must be ordered: %v", bound)
+		}
+
+		expr, err := operandExpression(bound)
+		if err != nil {
+			return "", fmt.Errorf("range bound: %w", err)
+		}
+		rendered = append(rendered, expr)
+	}
+
+	return fmt.Sprintf("%s %s %s AND %s", expression, operation, rendered[0], rendered[1]), nil
+}
+
+// distinctExpression - builds IS [NOT] DISTINCT FROM expression,
+// which compares nulls as regular values
+func (b *booleanExp) distinctExpression(expression, operation string) (string, error) {
+	right, err := operandExpression(b.right)
+	if err != nil {
+		return "", fmt.Errorf("right expression: %w", err)
+	}
+
+	return fmt.Sprintf("%s %s %s", expression, operation, right), nil
+}
+
+// operandExpression - builds field expression or serializes value
+func operandExpression(val any) (string, error) {
+	if f, ok := val.(Field); ok && !util.IsNil(f) {
+		return f.Expression()
+	}
+
+	expr := util.Serialize(val)
+	if len(expr) == 0 {
+		return "", fmt.Errorf("unsupported type of operand: %T", val)
+	}
+
+	return expr, nil
+}
+
 // Left - returns left field of the boolean expression
 func (b *booleanExp) Left() []Field {
 	return []Field{b.left}
 }
 
-// Right - returns right value of the boolean expression
+// Right - returns right value of the boolean expression.
+// Bounds of range are returned as separate values
 func (b *booleanExp) Right() []any {
+	if b.operation == between || b.operation == notBetween {
+		if bounds, ok := b.right.([]any); ok {
+			return bounds
+		}
+	}
 	return []any{b.right}
 }
@@ -63,6 +63,30 @@ func TestBooleanExpression(t *testing.T) {
 			wantExpr:  "schema.col = 3.14",
 			expectErr: false,
 		},
+		{
+			name:      "Greater field",
+			left:      F("schema.col"),
+			right:     F("schema.other"),
+			op:        more,
+			wantExpr:  "schema.col > schema.other",
+			expectErr: false,
+		},
+		{
+			name:      "Equal string with quote",
+			left:      F("schema.col"),
+			right:     "O'Brien",
+			op:        equal,
+			wantExpr:  "schema.col = 'O''Brien'",
+			expectErr: false,
+		},
+		{
+			name:      "NotIn list with quotes",
+			left:      F("schema.col"),
+			right:     []string{"a'; DROP STREAM s; --", "b"},
+			op:        notIn,
+			wantExpr:  "schema.col NOT IN ('a''; DROP STREAM s; --', 'b')",
+			expectErr: false,
+		},
 		{
 			name:      "NotEqual string",
 			left:      F("schema.col"),
@@ -160,6 +184,78 @@ func TestBooleanExpression(t *testing.T) {
 			wantExpr:  "schema.col NOT IN (1, 'a', FALSE)",
 			expectErr: false,
 		},
+		{
+			name:      "Like pattern",
+			left:      F("schema.col"),
+			right:     "ab%",
+			op:        like,
+			wantExpr:  "schema.col LIKE 'ab%'",
+			expectErr: false,
+		},
+		{
+			name:      "NotLike pattern",
+			left:      F("schema.col"),
+			right:     "it's%",
+			op:        notLike,
+			wantExpr:  "schema.col NOT LIKE 'it''s%'",
+			expectErr: false,
+		},
+		{
+			name:      "Like non string pattern",
+			left:      F("schema.col"),
+			right:     10,
+			op:        like,
+			wantExpr:  "",
+			expectErr: true,
+		},
+		{
+			name:      "Between ints",
+			left:      F("schema.col"),
+			right:     []any{1, 10},
+			op:        between,
+			wantExpr:  "schema.col BETWEEN 1 AND 10",
+			expectErr: false,
+		},
+		{
+			name:      "NotBetween fields",
+			left:      F("schema.col"),
+			right:     []any{F("schema.low"), F("schema.high")},
+			op:        notBetween,
+			wantExpr:  "schema.col NOT BETWEEN schema.low AND schema.high",
+			expectErr: false,
+		},
+		{
+			name:      "Between single bound",
+			left:      F("schema.col"),
+			right:     []any{1},
+			op:        between,
+			wantExpr:  "",
+			expectErr: true,
+		},
+		{
+			name:      "Between unordered bounds",
+			left:      F("schema.col"),
+			right:     []any{true, false},
+			op:        between,
+			wantExpr:  "",
+			expectErr: true,
+		},
+		{
+			name:      "IsDistinctFrom value",
+			left:      F("schema.col"),
+			right:     "foo",
+			op:        isDistinctFrom,
+			wantExpr:  "schema.col IS DISTINCT FROM 'foo'",
+			expectErr: false,
+		},
+		{
+			name:      "IsNotDistinctFrom null",
+			left:      F("schema.col"),
+			right:     nil,
+			op:        isNotDistinctFrom,
+			wantExpr:  "schema.col IS NOT DISTINCT FROM NULL",
+			expectErr: false,
+		},
 
 		{
 			name:      "Greater int",
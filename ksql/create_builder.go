@@ -65,10 +65,22 @@ var (
 		description: "Cannot create a stream from a table",
 	}
 
+	// 3. Persistent queries cannot be limited.
+	limitedAsSelect = createBuilderRule{
+		ruleFn: func(builder *createBuilder) bool {
+			if builder.asSelect == nil {
+				return true
+			}
+			return !builder.asSelect.limited()
+		},
+		description: "CREATE AS SELECT statements don't support LIMIT clause",
+	}
+
 	// createRuleSet contains the rules for validating create statements.
 	createRuleSet = []createBuilderRule{
 		tableFromNotAggregatedStream,
 		streamFromTable,
+		limitedAsSelect,
 	}
 )
 
@@ -184,6 +184,18 @@ func Test_CreateAsSelectExpression(t *testing.T) {
 			expected:  "CREATE TABLE table_name AS SELECT table1.column1, table2.column2 FROM table1 JOIN table2 ON table1.id = table2.id;",
 			expectErr: false,
 		},
+		{
+			name: "Create Stream with limited SELECT (invalid)",
+			createSQL: Create(STREAM, "stream_name").
+				AsSelect(
+					Select(F("stream1.column1")).
+						From(Schema("stream1", STREAM)).
+						EmitChanges().
+						Limit(10),
+				),
+			expected:  "",
+			expectErr: true,
+		},
 		{
 			name: "Create Stream with SELECT, WHERE, and ORDER BY",
 			createSQL: Create(STREAM, "stream_name").
@@ -25,6 +25,7 @@ type (
 const (
 	OrType = BooleanOperationType(iota)
 	AndType
+	NotType
 )
 
 // Or creates a new ExpressionList with the specified conditionals combined using OR operation
@@ -43,6 +44,14 @@ func And(exps ...Conditional) ExpressionList {
 	}
 }
 
+// Not creates a new ExpressionList, which negates the specified conditionals combined using AND operation
+func Not(exps ...Conditional) ExpressionList {
+	return &expressionList{
+		expressions: exps,
+		opType:      NotType,
+	}
+}
+
 // Conditionals returns all the conditionals in the expression list
 func (el *expressionList) Conditionals() []Conditional {
 	exps := make([]Conditional, len(el.expressions))
@@ -86,6 +95,9 @@ func (el *expressionList) Expression() (string, error) {
 		operation = " OR "
 	case AndType:
 		operation = " AND "
+	case NotType:
+		operation = " AND "
+		builder.WriteString("NOT ")
 	default:
 		return "", fmt.Errorf("unsupported boolean operation type: %d", el.opType)
 	}
@@ -34,6 +34,13 @@ func Test_ExpressionList(t *testing.T) {
 			wantExpr:  "( col1 IS NULL OR col2 IS NOT NULL )",
 			expectErr: false,
 		},
+		{
+			name:      "Multiple Expressions with NOT",
+			exprs:     []Conditional{F("col1").Like("a%"), F("col2").Between(1, 10)},
+			typ:       NotType,
+			wantExpr:  "NOT ( col1 LIKE 'a%' AND col2 BETWEEN 1 AND 10 )",
+			expectErr: false,
+		},
 		{
 			name:      "Empty Expression List",
 			exprs:     []Conditional{},
@@ -50,6 +57,8 @@ func Test_ExpressionList(t *testing.T) {
 				exprList = And(tt.exprs...)
 			} else if tt.typ == OrType {
 				exprList = Or(tt.exprs...)
+			} else if tt.typ == NotType {
+				exprList = Not(tt.exprs...)
 			}
 
 			expr, err := exprList.Expression()
@@ -25,6 +25,7 @@ type (
 		ComparableArray
 		Expression
 		Relational
+		Nested
 
 		// InnerRelations is a method for extracting relations from
 		// complex fields like arithmetic expressions or functions
@@ -313,3 +314,83 @@ func (af *aggregatedField) Expression() (string, error) {
 	}
 	return expression, nil
 }
+
+// IsTrue returns a Conditional expression to check if the field is true
+func (f *field) IsTrue() Conditional {
+	return NewBooleanExp(f.Copy(), nil, isTrue)
+}
+
+// IsFalse returns a Conditional expression to check if the field is false
+func (f *field) IsFalse() Conditional {
+	return NewBooleanExp(f.Copy(), nil, isFalse)
+}
+
+// Like returns a Conditional expression to check if the field matches the pattern
+func (f *field) Like(pattern any) Conditional {
+	return NewBooleanExp(f.Copy(), pattern, like)
+}
+
+// NotLike returns a Conditional expression to check if the field doesn't match the pattern
+func (f *field) NotLike(pattern any) Conditional {
+	return NewBooleanExp(f.Copy(), pattern, notLike)
+}
+
+// Between returns a Conditional expression to check if the field is in the inclusive range
+func (f *field) Between(from, to any) Conditional {
+	return NewBooleanExp(f.Copy(), []any{from, to}, between)
+}
+
+// NotBetween returns a Conditional expression to check if the field is out of the inclusive range
+func (f *field) NotBetween(from, to any) Conditional {
+	return NewBooleanExp(f.Copy(), []any{from, to}, notBetween)
+}
+
+// IsDistinctFrom returns a Conditional expression to check if the field differs from the value, nulls are compared as values
+func (f *field) IsDistinctFrom(val any) Conditional {
+	return NewBooleanExp(f.Copy(), val, isDistinctFrom)
+}
+
+// IsNotDistinctFrom returns a Conditional expression to check if the field equals the value, nulls are compared as values
+func (f *field) IsNotDistinctFrom(val any) Conditional {
+	return NewBooleanExp(f.Copy(), val, isNotDistinctFrom)
+}
+
+// IsTrue returns a Conditional expression to check if the aggregated field is true
+func (af *aggregatedField) IsTrue() Conditional {
+	return NewBooleanExp(af, nil, isTrue)
+}
+
+// IsFalse returns a Conditional expression to check if the aggregated field is false
+func (af *aggregatedField) IsFalse() Conditional {
+	return NewBooleanExp(af, nil, isFalse)
+}
+
+// Like returns a Conditional expression to check if the aggregated field matches the pattern
+func (af *aggregatedField) Like(pattern any) Conditional {
+	return NewBooleanExp(af, pattern, like)
+}
+
+// NotLike returns a Conditional expression to check if the aggregated field doesn't match the pattern
+func (af *aggregatedField) NotLike(pattern any) Conditional {
+	return NewBooleanExp(af, pattern, notLike)
+}
+
+// Between returns a Conditional expression to check if the aggregated field is in the inclusive range
+func (af *aggregatedField) Between(from, to any) Conditional {
+	return NewBooleanExp(af, []any{from, to}, between)
+}
+
+// NotBetween returns a Conditional expression to check if the aggregated field is out of the inclusive range
+func (af *aggregatedField) NotBetween(from, to any) Conditional {
+	return NewBooleanExp(af, []any{from, to}, notBetween)
+}
+
+// IsDistinctFrom returns a Conditional expression to check if the aggregated field differs from the value, nulls are compared as values
+func (af *aggregatedField) IsDistinctFrom(val any) Conditional {
+	return NewBooleanExp(af, val, isDistinctFrom)
+}
+
+// IsNotDistinctFrom returns a Conditional expression to check if the aggregated field equals the value, nulls are compared as values
+func (af *aggregatedField) IsNotDistinctFrom(val any) Conditional {
+	return NewBooleanExp(af, val, isNotDistinctFrom)
+}
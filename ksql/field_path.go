@@ -0,0 +1,451 @@
+package ksql
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gulfstream-h/ksql/internal/util"
+	"github.com/gulfstream-h/ksql/kinds"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Nested - common contract for accessing nested values
+	// of STRUCT, ARRAY and MAP fields
+	Nested interface {
+		// Deref - accesses STRUCT field, like ADDRESS->CITY
+		Deref(name string) Field
+		// Index - accesses ARRAY element, like TAGS[1].
+		// Indexes start with 1, negative ones count from the end
+		Index(i int) Field
+		// Key - accesses MAP value, like ATTRS['k']
+		Key(key any) Field
+	}
+
+	// pathStep - single access operation of field path
+	pathStep struct {
+		operation pathOperation
+		name      string
+		index     int
+		key       any
+	}
+
+	// pathOperation - type of access to nested value
+	pathOperation int
+
+	// fieldPath - field, that accesses nested value of root field.
+	// Relation report records root column, since only
+	// it exists in relation schema
+	fieldPath struct {
+		root  Field
+		steps []pathStep
+		alias string
+	}
+)
+
+const (
+	pathDeref = pathOperation(iota)
+	pathIndex
+	pathKey
+)
+
+var (
+	_ Field = (*fieldPath)(nil)
+)
+
+// newFieldPath - creates path, which
+// appends access step to root field
+func newFieldPath(root Field, step pathStep) Field {
+	if path, ok := root.(*fieldPath); ok {
+		steps := make([]pathStep, 0, len(path.steps)+1)
+		steps = append(steps, path.steps...)
+
+		return &fieldPath{
+			root:  path.root,
+			steps: append(steps, step),
+		}
+	}
+
+	return &fieldPath{
+		root:  root,
+		steps: []pathStep{step},
+	}
+}
+
+// Deref - accesses field of STRUCT column
+func (f *field) Deref(name string) Field {
+	return newFieldPath(f.Copy(), pathStep{operation: pathDeref, name: name})
+}
+
+// Index - accesses element of ARRAY column
+func (f *field) Index(i int) Field {
+	return newFieldPath(f.Copy(), pathStep{operation: pathIndex, index: i})
+}
+
+// Key - accesses value of MAP column
+func (f *field) Key(k any) Field {
+	return newFieldPath(f.Copy(), pathStep{operation: pathKey, key: k})
+}
+
+// Deref - accesses field of STRUCT, returned by aggregate function
+func (af *aggregatedField) Deref(name string) Field {
+	return newFieldPath(NewAggregatedField(af.fn), pathStep{operation: pathDeref, name: name})
+}
+
+// Index - accesses element of ARRAY, returned by aggregate function
+func (af *aggregatedField) Index(i int) Field {
+	return newFieldPath(NewAggregatedField(af.fn), pathStep{operation: pathIndex, index: i})
+}
+
+// Key - accesses value of MAP, returned by aggregate function
+func (af *aggregatedField) Key(k any) Field {
+	return newFieldPath(NewAggregatedField(af.fn), pathStep{operation: pathKey, key: k})
+}
+
+// Deref - accesses field of STRUCT, returned by function
+func (s *scalarFunction) Deref(name string) Field {
+	return newFieldPath(s.Copy(), pathStep{operation: pathDeref, name: name})
+}
+
+// Index - accesses element of ARRAY, returned by function
+func (s *scalarFunction) Index(i int) Field {
+	return newFieldPath(s.Copy(), pathStep{operation: pathIndex, index: i})
+}
+
+// Key - accesses value of MAP, returned by function
+func (s *scalarFunction) Key(k any) Field {
+	return newFieldPath(s.Copy(), pathStep{operation: pathKey, key: k})
+}
+
+// Deref - arithmetic result has no nested values,
+// so the path is checked by server
+func (a *arithmeticExpr) Deref(name string) Field {
+	return newFieldPath(newArithmetic(a.left, a.right, a.operation), pathStep{operation: pathDeref, name: name})
+}
+
+// Index - arithmetic result has no nested values,
+// so the path is checked by server
+func (a *arithmeticExpr) Index(i int) Field {
+	return newFieldPath(newArithmetic(a.left, a.right, a.operation), pathStep{operation: pathIndex, index: i})
+}
+
+// Key - arithmetic result has no nested values,
+// so the path is checked by server
+func (a *arithmeticExpr) Key(k any) Field {
+	return newFieldPath(newArithmetic(a.left, a.right, a.operation), pathStep{operation: pathKey, key: k})
+}
+
+// Deref - CASE is always aliased, so it cannot be root of path
+func (c *caseExpression) Deref(name string) Field {
+	return newFieldPath(c, pathStep{operation: pathDeref, name: name})
+}
+
+// Index - CASE is always aliased, so it cannot be root of path
+func (c *caseExpression) Index(i int) Field {
+	return newFieldPath(c, pathStep{operation: pathIndex, index: i})
+}
+
+// Key - CASE is always aliased, so it cannot be root of path
+func (c *caseExpression) Key(k any) Field {
+	return newFieldPath(c, pathStep{operation: pathKey, key: k})
+}
+
+// Deref - accesses field of STRUCT lambda parameter
+func (v *lambdaVar) Deref(name string) Field {
+	return newFieldPath(v, pathStep{operation: pathDeref, name: name})
+}
+
+// Index - accesses element of ARRAY lambda parameter
+func (v *lambdaVar) Index(i int) Field {
+	return newFieldPath(v, pathStep{operation: pathIndex, index: i})
+}
+
+// Key - accesses value of MAP lambda parameter
+func (v *lambdaVar) Key(k any) Field {
+	return newFieldPath(v, pathStep{operation: pathKey, key: k})
+}
+
+// Deref - accesses field of nested STRUCT
+func (p *fieldPath) Deref(name string) Field {
+	return newFieldPath(p, pathStep{operation: pathDeref, name: name})
+}
+
+// Index - accesses element of nested ARRAY
+func (p *fieldPath) Index(i int) Field {
+	return newFieldPath(p, pathStep{operation: pathIndex, index: i})
+}
+
+// Key - accesses value of nested MAP
+func (p *fieldPath) Key(k any) Field {
+	return newFieldPath(p, pathStep{operation: pathKey, key: k})
+}
+
+// Expression - renders root field followed by access operators
+func (p *fieldPath) Expression() (string, error) {
+	if util.IsNil(p.root) {
+		return "", errors.New("field path requires root field")
+	}
+
+	if len(p.root.Alias()) > 0 {
+		return "", fmt.Errorf("aliased field %s cannot be root of path", p.root.Alias())
+	}
+
+	root, err := p.root.Expression()
+	if err != nil {
+		return "", fmt.Errorf("root field expression: %w", err)
+	}
+
+	var (
+		builder strings.Builder
+	)
+
+	builder.WriteString(root)
+
+	for _, step := range p.steps {
+		expr, err := step.expression()
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(expr)
+	}
+
+	if len(p.alias) > 0 {
+		builder.WriteString(" AS ")
+		builder.WriteString(p.alias)
+	}
+
+	return builder.String(), nil
+}
+
+// expression - renders single access operator
+func (s pathStep) expression() (string, error) {
+	switch s.operation {
+	case pathDeref:
+		if len(s.name) == 0 {
+			return "", errors.New("dereferenced field name cannot be empty")
+		}
+		return "->" + s.name, nil
+	case pathIndex:
+		if s.index == 0 {
+			return "", errors.New("array index starts with 1")
+		}
+		return "[" + strconv.Itoa(s.index) + "]", nil
+	case pathKey:
+		if exp, ok := s.key.(Expression); ok && !util.IsNil(s.key) {
+			expr, err := exp.Expression()
+			if err != nil {
+				return "", fmt.Errorf("map key expression: %w", err)
+			}
+			return "[" + expr + "]", nil
+		}
+
+		expr := util.Serialize(s.key)
+		if len(expr) == 0 || util.IsNil(s.key) {
+			return "", fmt.Errorf("unsupported type of map key: %T", s.key)
+		}
+		return "[" + expr + "]", nil
+	default:
+		return "", fmt.Errorf("unsupported path operation: %d", s.operation)
+	}
+}
+
+// Kind - returns type of nested value, if root type is known.
+// STRUCT fields are not typed by kinds
+func (p *fieldPath) Kind() kinds.Ktype {
+	return p.nestedKind(kindOf(p.root))
+}
+
+// nestedKind - returns type of nested value by type of root
+func (p *fieldPath) nestedKind(kind kinds.Ktype) kinds.Ktype {
+	for _, step := range p.steps {
+		switch step.operation {
+		case pathIndex:
+			kind = elementOf(kind)
+		case pathKey:
+			kind = valueOf(kind)
+		default:
+			kind = 0
+		}
+	}
+
+	return kind
+}
+
+// elementOf - returns type of array elements
+func elementOf(kind kinds.Ktype) kinds.Ktype {
+	switch kind {
+	case kinds.ArrInt:
+		return kinds.Int
+	case kinds.ArrBool:
+		return kinds.Bool
+	case kinds.ArrDouble:
+		return kinds.Double
+	case kinds.ArrString:
+		return kinds.String
+	case kinds.ArrBigInt:
+		return kinds.BigInt
+	case kinds.ArrBytes:
+		return kinds.Bytes
+	default:
+		return 0
+	}
+}
+
+// valueOf - returns type of map values
+func valueOf(kind kinds.Ktype) kinds.Ktype {
+	switch kind {
+	case kinds.MapInt:
+		return kinds.Int
+	case kinds.MapBool:
+		return kinds.Bool
+	case kinds.MapDouble:
+		return kinds.Double
+	case kinds.MapString:
+		return kinds.String
+	case kinds.MapBigInt:
+		return kinds.BigInt
+	case kinds.MapBytes:
+		return kinds.Bytes
+	default:
+		return 0
+	}
+}
+
+// InnerRelations - returns root column and columns used in map keys
+func (p *fieldPath) InnerRelations() []Relational {
+	relations := relationsOf(p.root)
+
+	for _, step := range p.steps {
+		if step.operation == pathKey {
+			relations = append(relations, relationsOf(step.key)...)
+		}
+	}
+
+	return relations
+}
+
+// nested value is computed from root column during query
+func (p *fieldPath) derived() bool { return true }
+
+// Schema returns the schema of the root field
+func (p *fieldPath) Schema() string { return p.root.Schema() }
+
+// Column returns the column name of the root field
+func (p *fieldPath) Column() string { return p.root.Column() }
+
+// As sets the alias for the nested value and returns the path itself
+func (p *fieldPath) As(alias string) Field {
+	p.alias = alias
+	return p
+}
+
+// Alias returns the alias of the nested value
+func (p *fieldPath) Alias() string { return p.alias }
+
+// Copy creates a copy of the path without alias
+func (p *fieldPath) Copy() Field {
+	return &fieldPath{
+		root:  p.root,
+		steps: p.steps,
+	}
+}
+
+// Equal returns a Conditional expression for equality comparison
+func (p *fieldPath) Equal(val any) Conditional {
+	return NewBooleanExp(p.Copy(), val, equal)
+}
+
+// NotEqual returns a Conditional expression for inequality comparison
+func (p *fieldPath) NotEqual(val any) Conditional {
+	return NewBooleanExp(p.Copy(), val, notEqual)
+}
+
+// Greater returns a Conditional expression for greater than comparison
+func (p *fieldPath) Greater(val any) Conditional {
+	return NewBooleanExp(p.Copy(), val, more)
+}
+
+// Less returns a Conditional expression for less than comparison
+func (p *fieldPath) Less(val any) Conditional {
+	return NewBooleanExp(p.Copy(), val, less)
+}
+
+// GreaterEq returns a Conditional expression for greater than or equal to comparison
+func (p *fieldPath) GreaterEq(val any) Conditional {
+	return NewBooleanExp(p.Copy(), val, moreEqual)
+}
+
+// LessEq returns a Conditional expression for less than or equal to comparison
+func (p *fieldPath) LessEq(val any) Conditional {
+	return NewBooleanExp(p.Copy(), val, lessEqual)
+}
+
+// IsNull returns a Conditional expression to check if the nested value is null
+func (p *fieldPath) IsNull() Conditional {
+	return NewBooleanExp(p.Copy(), nil, isNull)
+}
+
+// IsNotNull returns a Conditional expression to check if the nested value is not null
+func (p *fieldPath) IsNotNull() Conditional {
+	return NewBooleanExp(p.Copy(), nil, isNotNull)
+}
+
+// In returns a Conditional expression to check if the nested value is in the provided values
+func (p *fieldPath) In(val ...any) Conditional {
+	return NewBooleanExp(p.Copy(), val, in)
+}
+
+// NotIn returns a Conditional expression to check if the nested value is not in the provided values
+func (p *fieldPath) NotIn(val ...any) Conditional {
+	return NewBooleanExp(p.Copy(), val, notIn)
+}
+
+// Asc returns an OrderedExpression for ascending order
+func (p *fieldPath) Asc() OrderedExpression {
+	return newOrderedExpression(p.Copy(), Ascending)
+}
+
+// Desc returns an OrderedExpression for descending order
+func (p *fieldPath) Desc() OrderedExpression {
+	return newOrderedExpression(p.Copy(), Descending)
+}
+
+// IsTrue returns a Conditional expression to check if the nested value is true
+func (p *fieldPath) IsTrue() Conditional {
+	return NewBooleanExp(p.Copy(), nil, isTrue)
+}
+
+// IsFalse returns a Conditional expression to check if the nested value is false
+func (p *fieldPath) IsFalse() Conditional {
+	return NewBooleanExp(p.Copy(), nil, isFalse)
+}
+
+// Like returns a Conditional expression to check if the nested value matches the pattern
+func (p *fieldPath) Like(pattern any) Conditional {
+	return NewBooleanExp(p.Copy(), pattern, like)
+}
+
+// NotLike returns a Conditional expression to check if the nested value doesn't match the pattern
+func (p *fieldPath) NotLike(pattern any) Conditional {
+	return NewBooleanExp(p.Copy(), pattern, notLike)
+}
+
+// Between returns a Conditional expression to check if the nested value is in the inclusive range
+func (p *fieldPath) Between(from, to any) Conditional {
+	return NewBooleanExp(p.Copy(), []any{from, to}, between)
+}
+
+// NotBetween returns a Conditional expression to check if the nested value is out of the inclusive range
+func (p *fieldPath) NotBetween(from, to any) Conditional {
+	return NewBooleanExp(p.Copy(), []any{from, to}, notBetween)
+}
+
+// IsDistinctFrom returns a Conditional expression to check if the nested value differs from the value, nulls are compared as values
+func (p *fieldPath) IsDistinctFrom(val any) Conditional {
+	return NewBooleanExp(p.Copy(), val, isDistinctFrom)
+}
+
+// IsNotDistinctFrom returns a Conditional expression to check if the nested value equals the value, nulls are compared as values
+func (p *fieldPath) IsNotDistinctFrom(val any) Conditional {
+	return NewBooleanExp(p.Copy(), val, isNotDistinctFrom)
+}
@@ -0,0 +1,119 @@
+package ksql
+
+import (
+	"github.com/gulfstream-h/ksql/kinds"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFieldPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		field     Field
+		wantExpr  string
+		wantKind  kinds.Ktype
+		expectErr bool
+	}{
+		{
+			name:     "Struct dereference",
+			field:    F("ADDRESS").Deref("CITY"),
+			wantExpr: "ADDRESS->CITY",
+		},
+		{
+			name:     "Nested struct dereference with schema",
+			field:    F("users.ADDRESS").Deref("GEO").Deref("LAT"),
+			wantExpr: "users.ADDRESS->GEO->LAT",
+		},
+		{
+			name:     "Array index",
+			field:    F("TAGS").Index(1),
+			wantExpr: "TAGS[1]",
+		},
+		{
+			name:     "Negative array index",
+			field:    F("TAGS").Index(-1),
+			wantExpr: "TAGS[-1]",
+		},
+		{
+			name:     "Map key",
+			field:    F("ATTRS").Key("k"),
+			wantExpr: "ATTRS['k']",
+		},
+		{
+			name:     "Map key with quote",
+			field:    F("ATTRS").Key("it's"),
+			wantExpr: "ATTRS['it''s']",
+		},
+		{
+			name:     "Map key from column",
+			field:    F("ATTRS").Key(F("NAME")),
+			wantExpr: "ATTRS[NAME]",
+		},
+		{
+			name:     "Mixed path with alias",
+			field:    F("ORDER").Deref("ITEMS").Index(2).Deref("PRICE").As("price"),
+			wantExpr: "ORDER->ITEMS[2]->PRICE AS price",
+		},
+		{
+			name:     "Alias of root is dropped",
+			field:    F("ADDRESS").As("addr").Deref("CITY"),
+			wantExpr: "ADDRESS->CITY",
+		},
+		{
+			name:     "Index of typed function",
+			field:    Split(F("CSV"), ",").Index(1),
+			wantExpr: "SPLIT(CSV, ',')[1]",
+			wantKind: kinds.String,
+		},
+		{
+			name:     "Key of typed function",
+			field:    JSONRecords(F("PAYLOAD")).Key("id"),
+			wantExpr: "JSON_RECORDS(PAYLOAD)['id']",
+			wantKind: kinds.String,
+		},
+		{
+			name:      "Zero array index",
+			field:     F("TAGS").Index(0),
+			expectErr: true,
+		},
+		{
+			name:      "Empty dereferenced name",
+			field:     F("ADDRESS").Deref(""),
+			expectErr: true,
+		},
+		{
+			name:      "Nil map key",
+			field:     F("ATTRS").Key(nil),
+			expectErr: true,
+		},
+		{
+			name:      "Aliased CASE root",
+			field:     Case("c", CaseWhen(F("a").Equal(1), "x")).Else("y").Deref("B"),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := tt.field.Expression()
+			assert.Equal(t, tt.expectErr, err != nil)
+			if !tt.expectErr {
+				assert.Equal(t, tt.wantExpr, expr)
+				assert.Equal(t, tt.wantKind, tt.field.(*fieldPath).Kind())
+			}
+		})
+	}
+}
+
+func TestFieldPathConditional(t *testing.T) {
+	expr, err := Select(F("ID")).
+		From(Schema("users", STREAM)).
+		Where(
+			F("ADDRESS").Deref("CITY").Equal("Paris"),
+			F("TAGS").Index(1).IsNotNull(),
+		).
+		Expression()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT ID FROM users WHERE ADDRESS->CITY = 'Paris' AND TAGS[1] IS NOT NULL;", expr)
+}
@@ -9,6 +9,11 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// quotedStringer - value, which string representation is not trusted
+type quotedStringer string
+
+func (s quotedStringer) String() string { return string(s) }
+
 func normalizeInsertSQL(sql string) string {
 	re := regexp.MustCompile(`(?i)INSERT INTO (\w+) \((.+?)\) VALUES \((.+?)\);`)
 	matches := re.FindStringSubmatch(sql)
@@ -95,6 +100,24 @@ func Test_InsertExpression(t *testing.T) {
 			expected:  "INSERT INTO table_name (id, name) VALUES (1, NULL);",
 			expectErr: false,
 		},
+		{
+			name: "Insert with quoted string",
+			fields: Row{
+				"id":   7,
+				"name": "O'Brien",
+			},
+			expected:  "INSERT INTO table_name (id, name) VALUES (7, 'O''Brien');",
+			expectErr: false,
+		},
+		{
+			name: "Insert with quoted stringer",
+			fields: Row{
+				"id":   8,
+				"name": quotedStringer("x' OR 'a'='a"),
+			},
+			expected:  "INSERT INTO table_name (id, name) VALUES (8, 'x'' OR ''a''=''a');",
+			expectErr: false,
+		},
 		{
 			name: "Insert with numeric and string mix",
 			fields: Row{
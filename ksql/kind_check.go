@@ -0,0 +1,165 @@
+package ksql
+
+import (
+	"fmt"
+	"github.com/gulfstream-h/ksql/kinds"
+	"github.com/gulfstream-h/ksql/static"
+	"reflect"
+)
+
+// kindResolver - returns type of field, zero means it's unknown
+type kindResolver func(f Field) kinds.Ktype
+
+// cachedKinds - resolves types of columns from reflection cache.
+// relationOf maps field to the name of relation, which owns it.
+// Nested values are typed by their root, struct fields are skipped
+func cachedKinds(cache *static.Cache, relationOf func(f Field) string) kindResolver {
+	var (
+		resolve kindResolver
+	)
+
+	resolve = func(f Field) kinds.Ktype {
+		if p, ok := f.(*fieldPath); ok {
+			return p.nestedKind(resolve(p.root))
+		}
+
+		if t, ok := f.(typed); ok {
+			return t.Kind()
+		}
+
+		if f.derived() {
+			return 0
+		}
+
+		relation := relationOf(f)
+		if len(relation) == 0 {
+			return 0
+		}
+
+		fields, err := cache.FindRelationFields(relation)
+		if err != nil {
+			return 0
+		}
+
+		column, ok := fields[f.Column()]
+		if !ok {
+			return 0
+		}
+
+		return column.Kind
+	}
+
+	return resolve
+}
+
+// checkKinds - compares types of conditional operands and
+// returns error on first mismatch. Operands of unknown type
+// are skipped, so server remains the last line of defence
+func checkKinds(cond Conditional, resolve kindResolver) error {
+	switch c := cond.(type) {
+	case *expressionList:
+		for _, exp := range c.expressions {
+			if err := checkKinds(exp, resolve); err != nil {
+				return err
+			}
+		}
+	case *booleanExp:
+		return c.checkKinds(resolve)
+	}
+
+	return nil
+}
+
+// checkKinds - compares type of left field with right operand
+func (b *booleanExp) checkKinds(resolve kindResolver) error {
+	if b.left == nil {
+		return nil
+	}
+
+	left := resolve(b.left)
+	if left == 0 {
+		return nil
+	}
+
+	switch b.operation {
+	case isNull, isNotNull:
+		return nil
+	case isTrue, isFalse:
+		if left != kinds.Bool {
+			return fmt.Errorf("%s is %s, but compared with boolean",
+				b.left.Column(), left.GetKafkaRepresentation())
+		}
+		return nil
+	case like, notLike:
+		if left != kinds.String {
+			return fmt.Errorf("%s is %s, but matched with pattern",
+				b.left.Column(), left.GetKafkaRepresentation())
+		}
+		return b.checkOperand(left, b.right, resolve)
+	case in, notIn, between, notBetween:
+		values := reflect.ValueOf(b.right)
+		if values.Kind() != reflect.Slice && values.Kind() != reflect.Array {
+			return nil
+		}
+		for i := range values.Len() {
+			if err := b.checkOperand(left, values.Index(i).Interface(), resolve); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return b.checkOperand(left, b.right, resolve)
+	}
+}
+
+// checkOperand - compares type of left field with single right value
+func (b *booleanExp) checkOperand(left kinds.Ktype, operand any, resolve kindResolver) error {
+	var (
+		right kinds.Ktype
+	)
+
+	if f, ok := operand.(Field); ok {
+		right = resolve(f)
+	} else {
+		right = kindOf(operand)
+	}
+
+	if right == 0 || compatibleKinds(left, right) {
+		return nil
+	}
+
+	return fmt.Errorf("%s is %s, but compared with %s value %v",
+		b.left.Column(), left.GetKafkaRepresentation(), right.GetKafkaRepresentation(), operand)
+}
+
+// compatibleKinds - checks if values of types can be compared.
+// Numbers are comparable with each other, since ksql casts them
+func compatibleKinds(left, right kinds.Ktype) bool {
+	if left == right {
+		return true
+	}
+
+	if numeric(left) && numeric(right) {
+		return true
+	}
+
+	if element := elementOf(left); element != 0 {
+		return compatibleKinds(element, elementOf(right))
+	}
+
+	if value := valueOf(left); value != 0 {
+		return compatibleKinds(value, valueOf(right))
+	}
+
+	return false
+}
+
+// numeric - checks if type is number
+func numeric(kind kinds.Ktype) bool {
+	switch kind {
+	case kinds.Int, kinds.BigInt, kinds.Double:
+		return true
+	default:
+		return false
+	}
+}
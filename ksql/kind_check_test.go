@@ -0,0 +1,312 @@
+package ksql
+
+import (
+	"github.com/gulfstream-h/ksql/internal/schema"
+	"github.com/gulfstream-h/ksql/kinds"
+	"github.com/gulfstream-h/ksql/shared"
+	"github.com/gulfstream-h/ksql/static"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_KindCheck(t *testing.T) {
+	previous := static.ReflectionFlag
+	static.ReflectionFlag = true
+	defer func() {
+		static.ReflectionFlag = previous
+	}()
+
+	static.StreamsProjections.Set(
+		"KIND_CHECK_ORDERS",
+		shared.StreamSettings{SourceTopic: "orders"},
+		schema.RemoteFieldsRepresentation("KIND_CHECK_ORDERS", map[string]string{
+			"ID":     "INT",
+			"AMOUNT": "DOUBLE",
+			"NAME":   "VARCHAR",
+			"ACTIVE": "BOOL",
+			"TAGS":   "ARRAY<VARCHAR>",
+		}),
+	)
+
+	testcases := []struct {
+		name      string
+		builder   SelectBuilder
+		expectErr bool
+	}{
+		{
+			name: "Matching string",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("NAME").Equal("abc")),
+		},
+		{
+			name: "Integer compared with double column",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("AMOUNT").Greater(10)),
+		},
+		{
+			name: "Small unsigned integer compared with int column",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("ID").Equal(uint8(1))),
+		},
+		{
+			name: "Unsigned integers compared with double column",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(
+					F("AMOUNT").Greater(uint16(1)),
+					F("AMOUNT").NotEqual(uint32(2)),
+					F("AMOUNT").Less(uint64(3)),
+				),
+		},
+		{
+			name: "Small unsigned integer compared with string column",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("NAME").Equal(uint8(1))),
+			expectErr: true,
+		},
+		{
+			name: "String compared with double column",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("AMOUNT").Equal("abc")),
+			expectErr: true,
+		},
+		{
+			name: "Qualified column with mismatch",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("KIND_CHECK_ORDERS.ID").Equal(true)),
+			expectErr: true,
+		},
+		{
+			name: "Aliased relation with mismatch",
+			builder: Select(F("o.ID")).From(Schema("KIND_CHECK_ORDERS", STREAM).As("o")).
+				Where(F("o.ACTIVE").Equal(1)),
+			expectErr: true,
+		},
+		{
+			name: "IN with mismatched element",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("ID").In(1, 2, "three")),
+			expectErr: true,
+		},
+		{
+			name: "Mismatch inside OR",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(Or(F("NAME").Equal("a"), F("NAME").Equal(5))),
+			expectErr: true,
+		},
+		{
+			name: "Columns of different types",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("NAME").Equal(F("AMOUNT"))),
+			expectErr: true,
+		},
+		{
+			name: "Cast fixes column type",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(Cast(F("AMOUNT"), kinds.String).Equal("10.5")),
+		},
+		{
+			name: "Array element",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("TAGS").Key("x").IsNull(), Split(F("NAME"), ",").Index(1).Equal("a")),
+		},
+		{
+			name: "LIKE on string column",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("NAME").Like("a%")),
+		},
+		{
+			name: "LIKE on numeric column",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("AMOUNT").Like("1%")),
+			expectErr: true,
+		},
+		{
+			name: "BETWEEN with mismatched bound",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("AMOUNT").Between(1, "ten")),
+			expectErr: true,
+		},
+		{
+			name: "Mismatch inside NOT",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(Not(F("ID").IsDistinctFrom("abc"))),
+			expectErr: true,
+		},
+		{
+			name: "Array element of cached column",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("TAGS").Index(1).Equal("a")),
+		},
+		{
+			name: "Array element of cached column with mismatch",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("TAGS").Index(1).Equal(5)),
+			expectErr: true,
+		},
+		{
+			name: "Null check is not typed",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_ORDERS", STREAM)).
+				Where(F("AMOUNT").IsNull()),
+		},
+		{
+			name: "Unknown relation is skipped",
+			builder: Select(F("ID")).From(Schema("KIND_CHECK_UNKNOWN", STREAM)).
+				Where(F("AMOUNT").Equal("abc")),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.builder.Expression()
+			assert.Equal(t, tc.expectErr, err != nil, err)
+		})
+	}
+}
+
+func Test_CastExpression(t *testing.T) {
+	testcases := []struct {
+		name      string
+		fn        ScalarFunction
+		wantExpr  string
+		wantKind  kinds.Ktype
+		expectErr bool
+	}{
+		{
+			name:     "Cast to bigint",
+			fn:       Cast(F("ID"), kinds.BigInt),
+			wantExpr: "CAST(ID AS BIGINT)",
+			wantKind: kinds.BigInt,
+		},
+		{
+			name:     "Cast literal to varchar",
+			fn:       Cast(42, kinds.String),
+			wantExpr: "CAST(42 AS VARCHAR)",
+			wantKind: kinds.String,
+		},
+		{
+			name:     "Cast to array",
+			fn:       Cast(F("TAGS"), kinds.ArrString),
+			wantExpr: "CAST(TAGS AS ARRAY<VARCHAR>)",
+			wantKind: kinds.ArrString,
+		},
+		{
+			name:     "Cast with alias",
+			fn:       Cast(F("ID"), kinds.Double).As("id").(ScalarFunction),
+			wantExpr: "CAST(ID AS DOUBLE) AS id",
+			wantKind: kinds.Double,
+		},
+		{
+			name:      "Cast to unknown type",
+			fn:        Cast(F("ID"), 0),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := tc.fn.Expression()
+			assert.Equal(t, tc.expectErr, err != nil)
+			if !tc.expectErr {
+				assert.Equal(t, tc.wantExpr, expr)
+				assert.Equal(t, tc.wantKind, tc.fn.Kind())
+			}
+		})
+	}
+}
+
+func Test_SelectBuilderReflect(t *testing.T) {
+	previous := static.ReflectionFlag
+	defer func() {
+		static.ReflectionFlag = previous
+	}()
+
+	cache := static.NewCache()
+	cache.Streams.Set(
+		"REFLECT_ORDERS",
+		shared.StreamSettings{SourceTopic: "orders"},
+		schema.RemoteFieldsRepresentation("REFLECT_ORDERS", map[string]string{
+			"ID":   "INT",
+			"NAME": "VARCHAR",
+		}),
+	)
+
+	testcases := []struct {
+		name       string
+		global     bool
+		builder    func() SelectBuilder
+		expectErr  bool
+		wantReport bool
+	}{
+		{
+			name: "Bound cache is checked without global flag",
+			builder: func() SelectBuilder {
+				return Select(F("ID")).Reflect(cache).
+					From(Schema("REFLECT_ORDERS", STREAM)).
+					Where(F("NAME").Equal(1))
+			},
+			expectErr:  true,
+			wantReport: true,
+		},
+		{
+			name: "Cache is bound after fields are selected",
+			builder: func() SelectBuilder {
+				return Select(F("ID")).
+					From(Schema("REFLECT_ORDERS", STREAM)).
+					Where(F("NAME").Equal("abc")).
+					Reflect(cache)
+			},
+			wantReport: true,
+		},
+		{
+			name:   "Nil cache disables global flag",
+			global: true,
+			builder: func() SelectBuilder {
+				return Select(F("ID"), Ucase(F("NAME"))).Reflect(nil).
+					From(Schema("REFLECT_ORDERS", STREAM))
+			},
+		},
+		{
+			name: "Unbound builder without global flag",
+			builder: func() SelectBuilder {
+				return Select(Ucase(F("NAME"))).
+					From(Schema("REFLECT_ORDERS", STREAM))
+			},
+		},
+		{
+			name:   "Unbound builder follows global flag",
+			global: true,
+			builder: func() SelectBuilder {
+				return Select(Ucase(F("NAME"))).
+					From(Schema("REFLECT_ORDERS", STREAM))
+			},
+			expectErr:  true,
+			wantReport: true,
+		},
+		{
+			name: "CTE is bound with outer builder",
+			builder: func() SelectBuilder {
+				inner := Select(F("ID")).
+					From(Schema("REFLECT_ORDERS", STREAM)).
+					Where(F("ID").Equal("abc")).
+					As("inner")
+				return Select(F("ID")).
+					WithCTE(inner).
+					From(Schema("inner", STREAM)).
+					Reflect(cache)
+			},
+			expectErr:  true,
+			wantReport: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			static.ReflectionFlag = tc.global
+
+			builder := tc.builder()
+			_, err := builder.Expression()
+			assert.Equal(t, tc.expectErr, err != nil, err)
+			assert.Equal(t, tc.wantReport, builder.RelationReport() != nil)
+		})
+	}
+}
@@ -0,0 +1,440 @@
+package ksql
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gulfstream-h/ksql/internal/util"
+	"github.com/gulfstream-h/ksql/kinds"
+	"strconv"
+	"strings"
+)
+
+const (
+	// TRANSFORM - applies lambda to every element of array or entry of map
+	TRANSFORM = `TRANSFORM`
+	// FILTER - keeps elements of array or entries of map, matching lambda
+	FILTER = `FILTER`
+	// REDUCE - folds array or map into single value with lambda
+	REDUCE = `REDUCE`
+)
+
+type (
+	// lambdaExp - realization of ksql lambda expression,
+	// like x => x * 2 or (k, v) => v > 0. It's passed
+	// as argument to higher-order scalar functions
+	lambdaExp struct {
+		params []*lambdaVar
+		body   any
+		// depth - count of lambdas nested into body
+		// including itself. It's used to name parameters,
+		// so nested lambdas don't shadow outer ones
+		depth int
+	}
+
+	// lambdaVar - parameter of lambda expression. It can be
+	// used only inside of lambda body, where it's declared
+	lambdaVar struct {
+		name string
+		// scoped - count of lambda bodies, which are
+		// being built with variable at the moment
+		scoped int
+	}
+)
+
+var (
+	_ Field = (*lambdaVar)(nil)
+)
+
+// Transform - returns TRANSFORM(array, x => ...) function
+func Transform(array any, fn func(x Field) any) ScalarFunction {
+	lambda := newLambda(func(params ...Field) any {
+		if fn == nil {
+			return nil
+		}
+		return fn(params[0])
+	}, "x")
+
+	return newScalar(TRANSFORM, arrayOf(kindOf(lambda.body)), array, lambda)
+}
+
+// TransformMap - returns TRANSFORM(map, (k, v) => ..., (k, v) => ...)
+// function, which builds new keys and values of map entries
+func TransformMap(m any, key func(k, v Field) any, value func(k, v Field) any) ScalarFunction {
+	keyLambda := newLambda(func(params ...Field) any {
+		if key == nil {
+			return nil
+		}
+		return key(params[0], params[1])
+	}, "k", "v")
+
+	valueLambda := newLambda(func(params ...Field) any {
+		if value == nil {
+			return nil
+		}
+		return value(params[0], params[1])
+	}, "k", "v")
+
+	var kind kinds.Ktype
+	if kindOf(keyLambda.body) == kinds.String {
+		kind = mapOf(kindOf(valueLambda.body))
+	}
+
+	return newScalar(TRANSFORM, kind, m, keyLambda, valueLambda)
+}
+
+// Filter - returns FILTER(array, x => ...) function
+func Filter(array any, fn func(x Field) Conditional) ScalarFunction {
+	lambda := newLambda(func(params ...Field) any {
+		if fn == nil {
+			return nil
+		}
+		return fn(params[0])
+	}, "x")
+
+	return newScalar(FILTER, kindOf(array), array, lambda)
+}
+
+// FilterMap - returns FILTER(map, (k, v) => ...) function
+func FilterMap(m any, fn func(k, v Field) Conditional) ScalarFunction {
+	lambda := newLambda(func(params ...Field) any {
+		if fn == nil {
+			return nil
+		}
+		return fn(params[0], params[1])
+	}, "k", "v")
+
+	return newScalar(FILTER, kindOf(m), m, lambda)
+}
+
+// Reduce - returns REDUCE(array, initial, (s, x) => ...) function.
+// Lambda receives accumulated state and element of array
+func Reduce(array any, initial any, fn func(s, x Field) any) ScalarFunction {
+	lambda := newLambda(func(params ...Field) any {
+		if fn == nil {
+			return nil
+		}
+		return fn(params[0], params[1])
+	}, "s", "x")
+
+	return newScalar(REDUCE, kindOf(initial), array, initial, lambda)
+}
+
+// ReduceMap - returns REDUCE(map, initial, (s, k, v) => ...) function.
+// Lambda receives accumulated state, key and value of map entry
+func ReduceMap(m any, initial any, fn func(s, k, v Field) any) ScalarFunction {
+	lambda := newLambda(func(params ...Field) any {
+		if fn == nil {
+			return nil
+		}
+		return fn(params[0], params[1], params[2])
+	}, "s", "k", "v")
+
+	return newScalar(REDUCE, kindOf(initial), m, initial, lambda)
+}
+
+// newLambda - declares parameters and builds lambda body with them
+func newLambda(build func(params ...Field) any, names ...string) *lambdaExp {
+	var (
+		lambda = new(lambdaExp)
+		params = make([]Field, 0, len(names))
+	)
+
+	for _, name := range names {
+		param := &lambdaVar{name: name}
+		lambda.params = append(lambda.params, param)
+		params = append(params, param)
+	}
+
+	lambda.body = build(params...)
+	lambda.depth = lambdaDepth(lambda.body) + 1
+
+	// outer lambdas get numbered parameters
+	if lambda.depth > 1 {
+		for _, param := range lambda.params {
+			param.name += strconv.Itoa(lambda.depth)
+		}
+	}
+
+	return lambda
+}
+
+// lambdaDepth - returns the deepest nesting of lambdas in expression
+func lambdaDepth(val any) int {
+	var (
+		depth int
+	)
+
+	switch v := val.(type) {
+	case *lambdaExp:
+		return v.depth
+	case *scalarFunction:
+		for _, arg := range v.args {
+			depth = max(depth, lambdaDepth(arg))
+		}
+	case *arithmeticExpr:
+		depth = max(lambdaDepth(v.left), lambdaDepth(v.right))
+	case *fieldPath:
+		depth = lambdaDepth(v.root)
+		for _, step := range v.steps {
+			depth = max(depth, lambdaDepth(step.key))
+		}
+	case Conditional:
+		for _, f := range v.Left() {
+			depth = max(depth, lambdaDepth(f))
+		}
+		for _, right := range v.Right() {
+			depth = max(depth, lambdaDepth(right))
+		}
+	}
+
+	return depth
+}
+
+// Expression - builds lambda with parameters, which are
+// accessible only during building of lambda body
+func (l *lambdaExp) Expression() (string, error) {
+	if util.IsNil(l.body) {
+		return "", errors.New("lambda body is not defined")
+	}
+
+	names := make([]string, 0, len(l.params))
+	for _, param := range l.params {
+		names = append(names, param.name)
+		param.scoped++
+	}
+
+	defer func() {
+		for _, param := range l.params {
+			param.scoped--
+		}
+	}()
+
+	var (
+		body string
+		err  error
+	)
+
+	if exp, ok := l.body.(Expression); ok {
+		body, err = exp.Expression()
+		if err != nil {
+			return "", fmt.Errorf("lambda body expression: %w", err)
+		}
+	} else {
+		body = util.Serialize(l.body)
+		if len(body) == 0 {
+			return "", fmt.Errorf("unsupported type of lambda body: %T", l.body)
+		}
+	}
+
+	if len(names) == 1 {
+		return names[0] + " => " + body, nil
+	}
+
+	return "(" + strings.Join(names, ", ") + ") => " + body, nil
+}
+
+// InnerRelations - returns columns, used in lambda body.
+// Lambda parameters are not columns, so they are skipped
+func (l *lambdaExp) InnerRelations() []Relational {
+	return relationsOf(l.body)
+}
+
+// relationsOf - collects columns of field or conditional
+func relationsOf(val any) []Relational {
+	var (
+		relations []Relational
+	)
+
+	switch v := val.(type) {
+	case Field:
+		if util.IsNil(v) {
+			return nil
+		}
+
+		relations = append(relations, v.InnerRelations()...)
+		if !v.derived() {
+			relations = append(relations, v)
+		}
+	case Conditional:
+		for _, f := range v.Left() {
+			relations = append(relations, relationsOf(f)...)
+		}
+		for _, right := range v.Right() {
+			relations = append(relations, relationsOf(right)...)
+		}
+	}
+
+	return relations
+}
+
+// arrayOf - returns array type of elements kind
+func arrayOf(kind kinds.Ktype) kinds.Ktype {
+	switch kind {
+	case kinds.Int:
+		return kinds.ArrInt
+	case kinds.Bool:
+		return kinds.ArrBool
+	case kinds.Double:
+		return kinds.ArrDouble
+	case kinds.String:
+		return kinds.ArrString
+	case kinds.BigInt:
+		return kinds.ArrBigInt
+	case kinds.Bytes:
+		return kinds.ArrBytes
+	default:
+		return 0
+	}
+}
+
+// mapOf - returns type of map with values kind
+func mapOf(kind kinds.Ktype) kinds.Ktype {
+	switch kind {
+	case kinds.Int:
+		return kinds.MapInt
+	case kinds.Bool:
+		return kinds.MapBool
+	case kinds.Double:
+		return kinds.MapDouble
+	case kinds.String:
+		return kinds.MapString
+	case kinds.BigInt:
+		return kinds.MapBigInt
+	case kinds.Bytes:
+		return kinds.MapBytes
+	default:
+		return 0
+	}
+}
+
+// Expression - returns name of lambda parameter.
+// Parameter cannot be used outside of its lambda
+func (v *lambdaVar) Expression() (string, error) {
+	if v.scoped == 0 {
+		return "", fmt.Errorf("lambda variable %s is used outside of its scope", v.name)
+	}
+
+	return v.name, nil
+}
+
+// lambda parameter doesn't exist in relation
+func (v *lambdaVar) derived() bool { return true }
+
+// InnerRelations - lambda parameter has no inner relations
+func (v *lambdaVar) InnerRelations() []Relational { return nil }
+
+// Schema - lambda parameter doesn't belong to any relation
+func (v *lambdaVar) Schema() string { return "" }
+
+// Column - returns name of lambda parameter
+func (v *lambdaVar) Column() string { return v.name }
+
+// Alias - lambda parameter cannot be aliased
+func (v *lambdaVar) Alias() string { return "" }
+
+// As - lambda parameter cannot be aliased, so alias is ignored
+func (v *lambdaVar) As(string) Field { return v }
+
+// Copy - returns the same parameter, since
+// it's bound to lambda by identity
+func (v *lambdaVar) Copy() Field { return v }
+
+// Equal returns a Conditional expression for equality comparison
+func (v *lambdaVar) Equal(val any) Conditional {
+	return NewBooleanExp(v, val, equal)
+}
+
+// NotEqual returns a Conditional expression for inequality comparison
+func (v *lambdaVar) NotEqual(val any) Conditional {
+	return NewBooleanExp(v, val, notEqual)
+}
+
+// Greater returns a Conditional expression for greater than comparison
+func (v *lambdaVar) Greater(val any) Conditional {
+	return NewBooleanExp(v, val, more)
+}
+
+// Less returns a Conditional expression for less than comparison
+func (v *lambdaVar) Less(val any) Conditional {
+	return NewBooleanExp(v, val, less)
+}
+
+// GreaterEq returns a Conditional expression for greater than or equal to comparison
+func (v *lambdaVar) GreaterEq(val any) Conditional {
+	return NewBooleanExp(v, val, moreEqual)
+}
+
+// LessEq returns a Conditional expression for less than or equal to comparison
+func (v *lambdaVar) LessEq(val any) Conditional {
+	return NewBooleanExp(v, val, lessEqual)
+}
+
+// IsNull returns a Conditional expression to check if the parameter is null
+func (v *lambdaVar) IsNull() Conditional {
+	return NewBooleanExp(v, nil, isNull)
+}
+
+// IsNotNull returns a Conditional expression to check if the parameter is not null
+func (v *lambdaVar) IsNotNull() Conditional {
+	return NewBooleanExp(v, nil, isNotNull)
+}
+
+// In returns a Conditional expression to check if the parameter is in the provided values
+func (v *lambdaVar) In(val ...any) Conditional {
+	return NewBooleanExp(v, val, in)
+}
+
+// NotIn returns a Conditional expression to check if the parameter is not in the provided values
+func (v *lambdaVar) NotIn(val ...any) Conditional {
+	return NewBooleanExp(v, val, notIn)
+}
+
+// Asc returns an OrderedExpression for ascending order
+func (v *lambdaVar) Asc() OrderedExpression {
+	return newOrderedExpression(v, Ascending)
+}
+
+// Desc returns an OrderedExpression for descending order
+func (v *lambdaVar) Desc() OrderedExpression {
+	return newOrderedExpression(v, Descending)
+}
+
+// IsTrue returns a Conditional expression to check if the parameter is true
+func (v *lambdaVar) IsTrue() Conditional {
+	return NewBooleanExp(v, nil, isTrue)
+}
+
+// IsFalse returns a Conditional expression to check if the parameter is false
+func (v *lambdaVar) IsFalse() Conditional {
+	return NewBooleanExp(v, nil, isFalse)
+}
+
+// Like returns a Conditional expression to check if the parameter matches the pattern
+func (v *lambdaVar) Like(pattern any) Conditional {
+	return NewBooleanExp(v, pattern, like)
+}
+
+// NotLike returns a Conditional expression to check if the parameter doesn't match the pattern
+func (v *lambdaVar) NotLike(pattern any) Conditional {
+	return NewBooleanExp(v, pattern, notLike)
+}
+
+// Between returns a Conditional expression to check if the parameter is in the inclusive range
+func (v *lambdaVar) Between(from, to any) Conditional {
+	return NewBooleanExp(v, []any{from, to}, between)
+}
+
+// NotBetween returns a Conditional expression to check if the parameter is out of the inclusive range
+func (v *lambdaVar) NotBetween(from, to any) Conditional {
+	return NewBooleanExp(v, []any{from, to}, notBetween)
+}
+
+// IsDistinctFrom returns a Conditional expression to check if the parameter differs from the value, nulls are compared as values
+func (v *lambdaVar) IsDistinctFrom(val any) Conditional {
+	return NewBooleanExp(v, val, isDistinctFrom)
+}
+
+// IsNotDistinctFrom returns a Conditional expression to check if the parameter equals the value, nulls are compared as values
+func (v *lambdaVar) IsNotDistinctFrom(val any) Conditional {
+	return NewBooleanExp(v, val, isNotDistinctFrom)
+}
@@ -0,0 +1,151 @@
+package ksql
+
+import (
+	"github.com/gulfstream-h/ksql/kinds"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_LambdaFn(t *testing.T) {
+	testcases := []struct {
+		name      string
+		fn        ScalarFunction
+		wantExpr  string
+		wantKind  kinds.Ktype
+		expectErr bool
+	}{
+		{
+			name: "Transform array",
+			fn: Transform(F("prices"), func(x Field) any {
+				return Mul(x, 2)
+			}),
+			wantExpr: "TRANSFORM(prices, x => ( x * 2 ))",
+		},
+		{
+			name: "Transform array with typed function",
+			fn: Transform(F("names"), func(x Field) any {
+				return Ucase(x)
+			}),
+			wantExpr: "TRANSFORM(names, x => UCASE(x))",
+			wantKind: kinds.ArrString,
+		},
+		{
+			name: "Transform map",
+			fn: TransformMap(F("scores"),
+				func(k, v Field) any { return Lcase(k) },
+				func(k, v Field) any { return Add(v, 1) },
+			),
+			wantExpr: "TRANSFORM(scores, (k, v) => LCASE(k), (k, v) => ( v + 1 ))",
+		},
+		{
+			name: "Filter array",
+			fn: Filter(Split(F("tags"), ","), func(x Field) Conditional {
+				return x.NotEqual("")
+			}),
+			wantExpr: "FILTER(SPLIT(tags, ','), x => x != '')",
+			wantKind: kinds.ArrString,
+		},
+		{
+			name: "Filter map",
+			fn: FilterMap(F("scores"), func(k, v Field) Conditional {
+				return And(k.In("a", "b"), v.Greater(10))
+			}),
+			wantExpr: "FILTER(scores, (k, v) => ( k IN ('a', 'b') AND v > 10 ))",
+		},
+		{
+			name: "Reduce array",
+			fn: Reduce(F("amounts"), 0.0, func(s, x Field) any {
+				return Add(s, x)
+			}),
+			wantExpr: "REDUCE(amounts, 0, (s, x) => ( s + x ))",
+			wantKind: kinds.Double,
+		},
+		{
+			name: "Reduce map",
+			fn: ReduceMap(F("counters"), 0, func(s, k, v Field) any {
+				return Add(s, v)
+			}),
+			wantExpr: "REDUCE(counters, 0, (s, k, v) => ( s + v ))",
+			wantKind: kinds.Int,
+		},
+		{
+			name: "Nested lambdas don't shadow parameters",
+			fn: Transform(F("matrix"), func(row Field) any {
+				return Filter(row, func(x Field) Conditional {
+					return x.Greater(row)
+				})
+			}),
+			wantExpr: "TRANSFORM(matrix, x2 => FILTER(x2, x => x > x2))",
+		},
+		{
+			name: "Lambda with column from relation",
+			fn: Filter(F("items"), func(x Field) Conditional {
+				return x.GreaterEq(F("threshold"))
+			}),
+			wantExpr: "FILTER(items, x => x >= threshold)",
+		},
+		{
+			name:      "Lambda without body",
+			fn:        Transform(F("prices"), nil),
+			expectErr: true,
+		},
+		{
+			name: "Lambda returning nil conditional",
+			fn: Filter(F("prices"), func(x Field) Conditional {
+				return nil
+			}),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := tc.fn.Expression()
+			assert.Equal(t, tc.expectErr, err != nil)
+			if !tc.expectErr {
+				assert.Equal(t, tc.wantExpr, expr)
+				assert.Equal(t, tc.wantKind, tc.fn.Kind())
+			}
+		})
+	}
+}
+
+func Test_LambdaScope(t *testing.T) {
+	var leaked Field
+
+	fn := Transform(F("prices"), func(x Field) any {
+		leaked = x
+		return Mul(x, 2)
+	})
+
+	_, err := fn.Expression()
+	assert.NoError(t, err)
+
+	_, err = leaked.Expression()
+	assert.Error(t, err)
+
+	_, err = Select(F("id"), fn.As("doubled")).
+		From(Schema("orders", STREAM)).
+		Where(leaked.Greater(10)).
+		Expression()
+	assert.Error(t, err)
+
+	_, err = Filter(leaked, func(x Field) Conditional {
+		return x.IsNotNull()
+	}).Expression()
+	assert.Error(t, err)
+}
+
+func Test_LambdaInnerRelations(t *testing.T) {
+	fn := Filter(F("o.items"), func(x Field) Conditional {
+		return x.Greater(F("o.threshold"))
+	})
+
+	relations := fn.InnerRelations()
+	columns := make([]string, 0, len(relations))
+	for _, rel := range relations {
+		columns = append(columns, rel.Schema()+"."+rel.Column())
+	}
+
+	assert.Equal(t, []string{"o.items", "o.threshold"}, columns)
+}
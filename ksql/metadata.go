@@ -2,6 +2,7 @@ package ksql
 
 import (
 	"fmt"
+	"github.com/gulfstream-h/ksql/internal/util"
 	"strings"
 )
 
@@ -25,13 +26,13 @@ func (m *Metadata) Expression() string {
 	)
 
 	if m.Topic != "" {
-		parts = append(parts, fmt.Sprintf("KAFKA_TOPIC = '%s'", m.Topic))
+		parts = append(parts, "KAFKA_TOPIC = "+util.Quote(m.Topic))
 	}
 	if m.ValueFormat != "" {
-		parts = append(parts, fmt.Sprintf("VALUE_FORMAT = '%s'", m.ValueFormat))
+		parts = append(parts, "VALUE_FORMAT = "+util.Quote(m.ValueFormat))
 	}
 	if m.KeyFormat != "" {
-		parts = append(parts, fmt.Sprintf("KEY_FORMAT = '%s'", m.KeyFormat))
+		parts = append(parts, "KEY_FORMAT = "+util.Quote(m.KeyFormat))
 	}
 	if m.Partitions != 0 {
 		parts = append(parts, fmt.Sprintf("PARTITIONS = %d", m.Partitions))
@@ -40,10 +41,10 @@ func (m *Metadata) Expression() string {
 		parts = append(parts, fmt.Sprintf("REPLICAS = %d", m.Replicas))
 	}
 	if m.Timestamp != "" {
-		parts = append(parts, fmt.Sprintf("TIMESTAMP = '%s'", m.Timestamp))
+		parts = append(parts, "TIMESTAMP = "+util.Quote(m.Timestamp))
 	}
 	if m.TimestampFormat != "" {
-		parts = append(parts, fmt.Sprintf("TIMESTAMP_FORMAT = '%s'", m.TimestampFormat))
+		parts = append(parts, "TIMESTAMP_FORMAT = "+util.Quote(m.TimestampFormat))
 	}
 
 	if len(parts) != 0 {
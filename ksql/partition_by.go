@@ -0,0 +1,74 @@
+package ksql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PartitionExpression - common contract for all PARTITION BY expressions
+type PartitionExpression interface {
+	Expression
+
+	PartitionedFields() []Field
+	IsEmpty() bool
+	PartitionBy(fields ...Field) PartitionExpression
+}
+
+// partition implements the PartitionExpression interface for re-keying streams in KSQL
+type partition struct {
+	fields []Field
+}
+
+// NewPartitionByExpression creates a new PartitionExpression instance
+func NewPartitionByExpression() PartitionExpression {
+	return &partition{}
+}
+
+// IsEmpty checks if the PARTITION BY expression has no fields
+func (p *partition) IsEmpty() bool {
+	return len(p.fields) == 0
+}
+
+// PartitionedFields returns a copy of the fields used in the PARTITION BY expression
+func (p *partition) PartitionedFields() []Field {
+	fields := make([]Field, len(p.fields))
+	copy(fields, p.fields)
+	return fields
+}
+
+// PartitionBy adds one or more fields to the PARTITION BY expression
+func (p *partition) PartitionBy(fields ...Field) PartitionExpression {
+	p.fields = append(p.fields, fields...)
+	return p
+}
+
+// Expression generates the PARTITION BY SQL expression based on the fields provided
+func (p *partition) Expression() (string, error) {
+	if len(p.fields) == 0 {
+		return "", errors.New("cannot create PARTITION BY expression with no fields")
+	}
+
+	var (
+		builder = new(strings.Builder)
+		isFirst = true
+	)
+
+	builder.WriteString("PARTITION BY ")
+
+	for i := range p.fields {
+		ex, err := p.fields[i].Expression()
+		if err != nil {
+			return "", fmt.Errorf("field expression: %w", err)
+		}
+
+		if !isFirst {
+			builder.WriteString(", ")
+		}
+
+		builder.WriteString(ex)
+		isFirst = false
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,63 @@
+package ksql
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPartitionByExpression(t *testing.T) {
+	tests := []struct {
+		name      string
+		fields    []Field
+		wantExpr  string
+		expectErr bool
+	}{
+		{
+			name:      "Single field",
+			fields:    []Field{F("schema.col")},
+			wantExpr:  "PARTITION BY schema.col",
+			expectErr: false,
+		},
+		{
+			name:      "Multiple fields",
+			fields:    []Field{F("schema.col1"), F("schema.col2")},
+			wantExpr:  "PARTITION BY schema.col1, schema.col2",
+			expectErr: false,
+		},
+		{
+			name:      "Empty fields",
+			fields:    []Field{},
+			wantExpr:  "",
+			expectErr: true,
+		},
+		{
+			name:      "Field with only column",
+			fields:    []Field{F("col")},
+			wantExpr:  "PARTITION BY col",
+			expectErr: false,
+		},
+		{
+			name:      "Invalid field",
+			fields:    []Field{F("")},
+			wantExpr:  "",
+			expectErr: true,
+		},
+		{
+			name:      "Multiple fields with mixed validity",
+			fields:    []Field{F("schema.col1"), F(""), F("schema.col2")},
+			wantExpr:  "",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partition := NewPartitionByExpression().PartitionBy(tt.fields...)
+			got, err := partition.Expression()
+			assert.Equal(t, tt.expectErr, err != nil)
+			if !tt.expectErr {
+				assert.Equal(t, tt.wantExpr, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,698 @@
+package ksql
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gulfstream-h/ksql/internal/util"
+	"github.com/gulfstream-h/ksql/kinds"
+	"reflect"
+	"strings"
+)
+
+const (
+	// string functions
+
+	// UCASE - converts string to upper case
+	UCASE = `UCASE`
+	// LCASE - converts string to lower case
+	LCASE = `LCASE`
+	// INITCAP - capitalizes first letter of every word
+	INITCAP = `INITCAP`
+	// TRIM - removes leading and trailing whitespaces
+	TRIM = `TRIM`
+	// LTRIM - removes leading whitespaces
+	LTRIM = `LTRIM`
+	// RTRIM - removes trailing whitespaces
+	RTRIM = `RTRIM`
+	// LEN - returns length of string
+	LEN = `LEN`
+	// SUBSTRING - returns part of string, positions start with 1
+	SUBSTRING = `SUBSTRING`
+	// CONCAT - joins strings
+	CONCAT = `CONCAT`
+	// CONCAT_WS - joins strings with separator
+	CONCAT_WS = `CONCAT_WS`
+	// REPLACE - replaces all occurrences of substring
+	REPLACE = `REPLACE`
+	// INSTR - returns position of substring, 0 if it's not found
+	INSTR = `INSTR`
+	// LPAD - pads string from the left up to length
+	LPAD = `LPAD`
+	// RPAD - pads string from the right up to length
+	RPAD = `RPAD`
+	// SPLIT - splits string into array by delimiter
+	SPLIT = `SPLIT`
+	// REGEXP_EXTRACT - returns first substring matching pattern
+	REGEXP_EXTRACT = `REGEXP_EXTRACT`
+	// REGEXP_REPLACE - replaces all substrings matching pattern
+	REGEXP_REPLACE = `REGEXP_REPLACE`
+
+	// numeric functions
+
+	// ABS - returns absolute value
+	ABS = `ABS`
+	// CEIL - rounds value up
+	CEIL = `CEIL`
+	// FLOOR - rounds value down
+	FLOOR = `FLOOR`
+	// ROUND - rounds value to the nearest integer or to scale
+	ROUND = `ROUND`
+	// SQRT - returns square root
+	SQRT = `SQRT`
+	// POWER - raises base to exponent
+	POWER = `POWER`
+	// EXP - raises e to value
+	EXP = `EXP`
+	// LN - returns natural logarithm
+	LN = `LN`
+	// SIGN - returns -1, 0 or 1 depending on sign of value
+	SIGN = `SIGN`
+	// RANDOM - returns random number between 0 and 1
+	RANDOM = `RANDOM`
+	// GREATEST - returns the highest of values
+	GREATEST = `GREATEST`
+	// LEAST - returns the lowest of values
+	LEAST = `LEAST`
+
+	// date and time functions
+
+	// UNIX_TIMESTAMP - returns current time in milliseconds
+	UNIX_TIMESTAMP = `UNIX_TIMESTAMP`
+	// UNIX_DATE - returns current date in days since epoch
+	UNIX_DATE = `UNIX_DATE`
+	// FROM_UNIXTIME - converts milliseconds into TIMESTAMP
+	FROM_UNIXTIME = `FROM_UNIXTIME`
+	// TIMESTAMPTOSTRING - formats milliseconds with pattern
+	TIMESTAMPTOSTRING = `TIMESTAMPTOSTRING`
+	// STRINGTOTIMESTAMP - parses milliseconds from string with pattern
+	STRINGTOTIMESTAMP = `STRINGTOTIMESTAMP`
+	// FORMAT_TIMESTAMP - formats TIMESTAMP with pattern
+	FORMAT_TIMESTAMP = `FORMAT_TIMESTAMP`
+	// PARSE_TIMESTAMP - parses TIMESTAMP from string with pattern
+	PARSE_TIMESTAMP = `PARSE_TIMESTAMP`
+
+	// json functions
+
+	// EXTRACTJSONFIELD - returns value of json string by JSONPath
+	EXTRACTJSONFIELD = `EXTRACTJSONFIELD`
+	// IS_JSON_STRING - checks if string is valid json
+	IS_JSON_STRING = `IS_JSON_STRING`
+	// JSON_ARRAY_LENGTH - returns length of json array
+	JSON_ARRAY_LENGTH = `JSON_ARRAY_LENGTH`
+	// JSON_KEYS - returns keys of json object
+	JSON_KEYS = `JSON_KEYS`
+	// JSON_RECORDS - returns key-value pairs of json object
+	JSON_RECORDS = `JSON_RECORDS`
+	// TO_JSON_STRING - serializes value into json string
+	TO_JSON_STRING = `TO_JSON_STRING`
+
+	// null handling functions
+
+	// COALESCE - returns first non-null value
+	COALESCE = `COALESCE`
+	// IFNULL - returns alternative if value is null
+	IFNULL = `IFNULL`
+	// NULLIF - returns null if values are equal
+	NULLIF = `NULLIF`
+
+	// CAST - converts value to another type
+	CAST = `CAST`
+)
+
+type (
+	// ScalarFunction - common contract for functions,
+	// that are applied to every row and return typed value
+	ScalarFunction interface {
+		Field
+
+		Name() string
+		Arguments() []any
+		// Kind - returns type of function result.
+		// Zero means that type cannot be expressed with kinds
+		Kind() kinds.Ktype
+	}
+
+	// typed - contract of fields, that know their type
+	typed interface {
+		Kind() kinds.Ktype
+	}
+
+	// scalarFunction - basement structure of all scalar functions realizations
+	scalarFunction struct {
+		name  string
+		args  []any
+		alias string
+
+		// kind - constant type of result,
+		// it's used if result doesn't depend on arguments
+		kind kinds.Ktype
+		// inherits - function returns the same type,
+		// as its arguments have
+		inherits bool
+		// separator - delimiter of arguments, comma by default
+		separator string
+	}
+
+	// castType - target type of CAST, rendered as ksql type name
+	castType kinds.Ktype
+)
+
+var (
+	_ ScalarFunction = (*scalarFunction)(nil)
+)
+
+// newScalar - creates function with constant result type
+func newScalar(name string, kind kinds.Ktype, args ...any) ScalarFunction {
+	return &scalarFunction{
+		name: name,
+		args: args,
+		kind: kind,
+	}
+}
+
+// newInheritingScalar - creates function,
+// that returns type of its arguments
+func newInheritingScalar(name string, args ...any) ScalarFunction {
+	return &scalarFunction{
+		name:     name,
+		args:     args,
+		inherits: true,
+	}
+}
+
+// Ucase - returns UCASE(value) function
+func Ucase(val any) ScalarFunction {
+	return newScalar(UCASE, kinds.String, val)
+}
+
+// Lcase - returns LCASE(value) function
+func Lcase(val any) ScalarFunction {
+	return newScalar(LCASE, kinds.String, val)
+}
+
+// Initcap - returns INITCAP(value) function
+func Initcap(val any) ScalarFunction {
+	return newScalar(INITCAP, kinds.String, val)
+}
+
+// Trim - returns TRIM(value) function
+func Trim(val any) ScalarFunction {
+	return newScalar(TRIM, kinds.String, val)
+}
+
+// Ltrim - returns LTRIM(value) function
+func Ltrim(val any) ScalarFunction {
+	return newScalar(LTRIM, kinds.String, val)
+}
+
+// Rtrim - returns RTRIM(value) function
+func Rtrim(val any) ScalarFunction {
+	return newScalar(RTRIM, kinds.String, val)
+}
+
+// Len - returns LEN(value) function
+func Len(val any) ScalarFunction {
+	return newScalar(LEN, kinds.Int, val)
+}
+
+// Substring - returns SUBSTRING(value, position) function.
+// Optional length limits count of returned characters
+func Substring(val any, position int, length ...int) ScalarFunction {
+	args := []any{val, position}
+	if len(length) > 0 {
+		args = append(args, length[0])
+	}
+	return newScalar(SUBSTRING, kinds.String, args...)
+}
+
+// Concat - returns CONCAT(values...) function
+func Concat(vals ...any) ScalarFunction {
+	return newScalar(CONCAT, kinds.String, vals...)
+}
+
+// ConcatWS - returns CONCAT_WS(separator, values...) function
+func ConcatWS(separator any, vals ...any) ScalarFunction {
+	return newScalar(CONCAT_WS, kinds.String, append([]any{separator}, vals...)...)
+}
+
+// Replace - returns REPLACE(value, old, replacement) function
+func Replace(val any, old any, replacement any) ScalarFunction {
+	return newScalar(REPLACE, kinds.String, val, old, replacement)
+}
+
+// Instr - returns INSTR(value, substring) function
+func Instr(val any, substring any) ScalarFunction {
+	return newScalar(INSTR, kinds.Int, val, substring)
+}
+
+// Lpad - returns LPAD(value, length, padding) function
+func Lpad(val any, length int, padding any) ScalarFunction {
+	return newScalar(LPAD, kinds.String, val, length, padding)
+}
+
+// Rpad - returns RPAD(value, length, padding) function
+func Rpad(val any, length int, padding any) ScalarFunction {
+	return newScalar(RPAD, kinds.String, val, length, padding)
+}
+
+// Split - returns SPLIT(value, delimiter) function
+func Split(val any, delimiter any) ScalarFunction {
+	return newScalar(SPLIT, kinds.ArrString, val, delimiter)
+}
+
+// RegexpExtract - returns REGEXP_EXTRACT(pattern, value) function
+func RegexpExtract(pattern string, val any) ScalarFunction {
+	return newScalar(REGEXP_EXTRACT, kinds.String, pattern, val)
+}
+
+// RegexpReplace - returns REGEXP_REPLACE(value, pattern, replacement) function
+func RegexpReplace(val any, pattern string, replacement any) ScalarFunction {
+	return newScalar(REGEXP_REPLACE, kinds.String, val, pattern, replacement)
+}
+
+// Abs - returns ABS(value) function
+func Abs(val any) ScalarFunction {
+	return newInheritingScalar(ABS, val)
+}
+
+// Ceil - returns CEIL(value) function
+func Ceil(val any) ScalarFunction {
+	return newInheritingScalar(CEIL, val)
+}
+
+// Floor - returns FLOOR(value) function
+func Floor(val any) ScalarFunction {
+	return newInheritingScalar(FLOOR, val)
+}
+
+// Round - returns ROUND(value) function
+// rounding to the nearest integer
+func Round(val any) ScalarFunction {
+	return newScalar(ROUND, kinds.BigInt, val)
+}
+
+// RoundTo - returns ROUND(value, scale) function
+// rounding to scale decimal places
+func RoundTo(val any, scale int) ScalarFunction {
+	return newScalar(ROUND, kinds.Double, val, scale)
+}
+
+// Sqrt - returns SQRT(value) function
+func Sqrt(val any) ScalarFunction {
+	return newScalar(SQRT, kinds.Double, val)
+}
+
+// Power - returns POWER(base, exponent) function
+func Power(base any, exponent any) ScalarFunction {
+	return newScalar(POWER, kinds.Double, base, exponent)
+}
+
+// Exp - returns EXP(value) function
+func Exp(val any) ScalarFunction {
+	return newScalar(EXP, kinds.Double, val)
+}
+
+// Ln - returns LN(value) function
+func Ln(val any) ScalarFunction {
+	return newScalar(LN, kinds.Double, val)
+}
+
+// Sign - returns SIGN(value) function
+func Sign(val any) ScalarFunction {
+	return newScalar(SIGN, kinds.Int, val)
+}
+
+// Random - returns RANDOM() function
+func Random() ScalarFunction {
+	return newScalar(RANDOM, kinds.Double)
+}
+
+// Greatest - returns GREATEST(values...) function
+func Greatest(val any, vals ...any) ScalarFunction {
+	return newInheritingScalar(GREATEST, append([]any{val}, vals...)...)
+}
+
+// Least - returns LEAST(values...) function
+func Least(val any, vals ...any) ScalarFunction {
+	return newInheritingScalar(LEAST, append([]any{val}, vals...)...)
+}
+
+// UnixTimestamp - returns UNIX_TIMESTAMP() function
+func UnixTimestamp() ScalarFunction {
+	return newScalar(UNIX_TIMESTAMP, kinds.BigInt)
+}
+
+// UnixDate - returns UNIX_DATE() function
+func UnixDate() ScalarFunction {
+	return newScalar(UNIX_DATE, kinds.Int)
+}
+
+// FromUnixtime - returns FROM_UNIXTIME(milliseconds) function.
+// TIMESTAMP result has no kinds representation
+func FromUnixtime(milliseconds any) ScalarFunction {
+	return newScalar(FROM_UNIXTIME, 0, milliseconds)
+}
+
+// TimestampToString - returns TIMESTAMPTOSTRING(milliseconds, pattern) function
+func TimestampToString(milliseconds any, pattern string) ScalarFunction {
+	return newScalar(TIMESTAMPTOSTRING, kinds.String, milliseconds, pattern)
+}
+
+// StringToTimestamp - returns STRINGTOTIMESTAMP(value, pattern) function
+func StringToTimestamp(val any, pattern string) ScalarFunction {
+	return newScalar(STRINGTOTIMESTAMP, kinds.BigInt, val, pattern)
+}
+
+// FormatTimestamp - returns FORMAT_TIMESTAMP(timestamp, pattern) function
+func FormatTimestamp(timestamp any, pattern string) ScalarFunction {
+	return newScalar(FORMAT_TIMESTAMP, kinds.String, timestamp, pattern)
+}
+
+// ParseTimestamp - returns PARSE_TIMESTAMP(value, pattern) function.
+// TIMESTAMP result has no kinds representation
+func ParseTimestamp(val any, pattern string) ScalarFunction {
+	return newScalar(PARSE_TIMESTAMP, 0, val, pattern)
+}
+
+// ExtractJSONField - returns EXTRACTJSONFIELD(value, path) function
+func ExtractJSONField(val any, path string) ScalarFunction {
+	return newScalar(EXTRACTJSONFIELD, kinds.String, val, path)
+}
+
+// IsJSONString - returns IS_JSON_STRING(value) function
+func IsJSONString(val any) ScalarFunction {
+	return newScalar(IS_JSON_STRING, kinds.Bool, val)
+}
+
+// JSONArrayLength - returns JSON_ARRAY_LENGTH(value) function
+func JSONArrayLength(val any) ScalarFunction {
+	return newScalar(JSON_ARRAY_LENGTH, kinds.Int, val)
+}
+
+// JSONKeys - returns JSON_KEYS(value) function
+func JSONKeys(val any) ScalarFunction {
+	return newScalar(JSON_KEYS, kinds.ArrString, val)
+}
+
+// JSONRecords - returns JSON_RECORDS(value) function
+func JSONRecords(val any) ScalarFunction {
+	return newScalar(JSON_RECORDS, kinds.MapString, val)
+}
+
+// ToJSONString - returns TO_JSON_STRING(value) function
+func ToJSONString(val any) ScalarFunction {
+	return newScalar(TO_JSON_STRING, kinds.String, val)
+}
+
+// Coalesce - returns COALESCE(values...) function
+func Coalesce(val any, vals ...any) ScalarFunction {
+	return newInheritingScalar(COALESCE, append([]any{val}, vals...)...)
+}
+
+// IfNull - returns IFNULL(value, alternative) function
+func IfNull(val any, alternative any) ScalarFunction {
+	return newInheritingScalar(IFNULL, val, alternative)
+}
+
+// NullIf - returns NULLIF(value, other) function
+func NullIf(val any, other any) ScalarFunction {
+	return newInheritingScalar(NULLIF, val, other)
+}
+
+// Cast - returns CAST(value AS type) function
+func Cast(val any, kind kinds.Ktype) ScalarFunction {
+	return &scalarFunction{
+		name:      CAST,
+		args:      []any{val, castType(kind)},
+		kind:      kind,
+		separator: " AS ",
+	}
+}
+
+// Expression - returns ksql representation of type
+func (c castType) Expression() (string, error) {
+	representation := kinds.Ktype(c).GetKafkaRepresentation()
+	if len(representation) == 0 {
+		return "", fmt.Errorf("unsupported cast type: %d", c)
+	}
+
+	return representation, nil
+}
+
+// Name - returns name of function. Like UCASE, ROUND etc...
+func (s *scalarFunction) Name() string {
+	return s.name
+}
+
+// Arguments - returns fields and values passed to function
+func (s *scalarFunction) Arguments() []any {
+	return s.args
+}
+
+// Kind - returns type of function result. Functions, that
+// inherit type, take first known type of arguments
+func (s *scalarFunction) Kind() kinds.Ktype {
+	if !s.inherits {
+		return s.kind
+	}
+
+	for _, arg := range s.args {
+		if kind := kindOf(arg); kind != 0 {
+			return kind
+		}
+	}
+
+	return 0
+}
+
+// kindOf - returns type of function argument. Columns
+// are typed by relation, so their kind is unknown
+func kindOf(arg any) kinds.Ktype {
+	switch v := arg.(type) {
+	case typed:
+		return v.Kind()
+	case Field:
+		return 0
+	case nil:
+		return 0
+	}
+
+	// literals are numbers, while type mapping of
+	// structures takes uint8 for element of BYTES
+	switch reflect.TypeOf(arg).Kind() {
+	case
+		reflect.Int,
+		reflect.Int8,
+		reflect.Int16,
+		reflect.Int32,
+		reflect.Uint8,
+		reflect.Uint16:
+		return kinds.Int
+	case
+		reflect.Int64,
+		reflect.Uint,
+		reflect.Uint32,
+		reflect.Uint64:
+		return kinds.BigInt
+	}
+
+	kind, err := kinds.ToKsql(reflect.TypeOf(arg))
+	if err != nil {
+		return 0
+	}
+
+	return kind
+}
+
+// Expression - builds function call with serialized arguments
+func (s *scalarFunction) Expression() (string, error) {
+	if len(s.name) == 0 {
+		return "", errors.New("scalar function name cannot be empty")
+	}
+
+	args := make([]string, 0, len(s.args))
+	for idx, arg := range s.args {
+		if exp, ok := arg.(Expression); ok && !util.IsNil(arg) {
+			expr, err := exp.Expression()
+			if err != nil {
+				return "", fmt.Errorf("%s argument %d expression: %w", s.name, idx, err)
+			}
+			args = append(args, expr)
+			continue
+		}
+
+		expr := util.Serialize(arg)
+		if len(expr) == 0 {
+			return "", fmt.Errorf("%s argument %d has unsupported type: %T", s.name, idx, arg)
+		}
+		args = append(args, expr)
+	}
+
+	separator := s.separator
+	if len(separator) == 0 {
+		separator = ", "
+	}
+
+	expression := s.name + "(" + strings.Join(args, separator) + ")"
+	if len(s.alias) > 0 {
+		expression += " AS " + s.alias
+	}
+
+	return expression, nil
+}
+
+// InnerRelations - returns columns, passed to function
+// and to its nested functions
+func (s *scalarFunction) InnerRelations() []Relational {
+	var (
+		relations []Relational
+	)
+
+	for _, arg := range s.args {
+		switch v := arg.(type) {
+		case *lambdaExp:
+			relations = append(relations, v.InnerRelations()...)
+		case Field:
+			relations = append(relations, relationsOf(v)...)
+		}
+	}
+
+	return relations
+}
+
+// scalar function computes new value during query
+func (s *scalarFunction) derived() bool {
+	return true
+}
+
+// Schema - function doesn't belong to any relation
+func (s *scalarFunction) Schema() string {
+	return ""
+}
+
+// Column - function has no column in relation
+func (s *scalarFunction) Column() string {
+	return ""
+}
+
+// As sets the alias for the function result and returns the function itself
+func (s *scalarFunction) As(alias string) Field {
+	s.alias = alias
+	return s
+}
+
+// Alias returns the alias of the function result
+func (s *scalarFunction) Alias() string {
+	return s.alias
+}
+
+// Copy creates a copy of the function without alias
+func (s *scalarFunction) Copy() Field {
+	return &scalarFunction{
+		name:      s.name,
+		args:      s.args,
+		kind:      s.kind,
+		inherits:  s.inherits,
+		separator: s.separator,
+	}
+}
+
+// Equal returns a Conditional expression for equality comparison
+func (s *scalarFunction) Equal(val any) Conditional {
+	return NewBooleanExp(s.Copy(), val, equal)
+}
+
+// NotEqual returns a Conditional expression for inequality comparison
+func (s *scalarFunction) NotEqual(val any) Conditional {
+	return NewBooleanExp(s.Copy(), val, notEqual)
+}
+
+// Greater returns a Conditional expression for greater than comparison
+func (s *scalarFunction) Greater(val any) Conditional {
+	return NewBooleanExp(s.Copy(), val, more)
+}
+
+// Less returns a Conditional expression for less than comparison
+func (s *scalarFunction) Less(val any) Conditional {
+	return NewBooleanExp(s.Copy(), val, less)
+}
+
+// GreaterEq returns a Conditional expression for greater than or equal to comparison
+func (s *scalarFunction) GreaterEq(val any) Conditional {
+	return NewBooleanExp(s.Copy(), val, moreEqual)
+}
+
+// LessEq returns a Conditional expression for less than or equal to comparison
+func (s *scalarFunction) LessEq(val any) Conditional {
+	return NewBooleanExp(s.Copy(), val, lessEqual)
+}
+
+// IsNull returns a Conditional expression to check if the function result is null
+func (s *scalarFunction) IsNull() Conditional {
+	return NewBooleanExp(s.Copy(), nil, isNull)
+}
+
+// IsNotNull returns a Conditional expression to check if the function result is not null
+func (s *scalarFunction) IsNotNull() Conditional {
+	return NewBooleanExp(s.Copy(), nil, isNotNull)
+}
+
+// In returns a Conditional expression to check if the function result is in the provided values
+func (s *scalarFunction) In(val ...any) Conditional {
+	return NewBooleanExp(s.Copy(), val, in)
+}
+
+// NotIn returns a Conditional expression to check if the function result is not in the provided values
+func (s *scalarFunction) NotIn(val ...any) Conditional {
+	return NewBooleanExp(s.Copy(), val, notIn)
+}
+
+// Asc returns an OrderedExpression for ascending order
+func (s *scalarFunction) Asc() OrderedExpression {
+	return newOrderedExpression(s.Copy(), Ascending)
+}
+
+// Desc returns an OrderedExpression for descending order
+func (s *scalarFunction) Desc() OrderedExpression {
+	return newOrderedExpression(s.Copy(), Descending)
+}
+
+// IsTrue returns a Conditional expression to check if the function result is true
+func (s *scalarFunction) IsTrue() Conditional {
+	return NewBooleanExp(s.Copy(), nil, isTrue)
+}
+
+// IsFalse returns a Conditional expression to check if the function result is false
+func (s *scalarFunction) IsFalse() Conditional {
+	return NewBooleanExp(s.Copy(), nil, isFalse)
+}
+
+// Like returns a Conditional expression to check if the function result matches the pattern
+func (s *scalarFunction) Like(pattern any) Conditional {
+	return NewBooleanExp(s.Copy(), pattern, like)
+}
+
+// NotLike returns a Conditional expression to check if the function result doesn't match the pattern
+func (s *scalarFunction) NotLike(pattern any) Conditional {
+	return NewBooleanExp(s.Copy(), pattern, notLike)
+}
+
+// Between returns a Conditional expression to check if the function result is in the inclusive range
+func (s *scalarFunction) Between(from, to any) Conditional {
+	return NewBooleanExp(s.Copy(), []any{from, to}, between)
+}
+
+// NotBetween returns a Conditional expression to check if the function result is out of the inclusive range
+func (s *scalarFunction) NotBetween(from, to any) Conditional {
+	return NewBooleanExp(s.Copy(), []any{from, to}, notBetween)
+}
+
+// IsDistinctFrom returns a Conditional expression to check if the function result differs from the value, nulls are compared as values
+func (s *scalarFunction) IsDistinctFrom(val any) Conditional {
+	return NewBooleanExp(s.Copy(), val, isDistinctFrom)
+}
+
+// IsNotDistinctFrom returns a Conditional expression to check if the function result equals the value, nulls are compared as values
+func (s *scalarFunction) IsNotDistinctFrom(val any) Conditional {
+	return NewBooleanExp(s.Copy(), val, isNotDistinctFrom)
+}
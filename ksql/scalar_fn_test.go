@@ -0,0 +1,214 @@
+package ksql
+
+import (
+	"github.com/gulfstream-h/ksql/kinds"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_ScalarFn(t *testing.T) {
+	testcases := []struct {
+		name      string
+		fn        ScalarFunction
+		wantExpr  string
+		wantKind  kinds.Ktype
+		expectErr bool
+	}{
+		{
+			name:     "Ucase function",
+			fn:       Ucase(F("name")),
+			wantExpr: "UCASE(name)",
+			wantKind: kinds.String,
+		},
+		{
+			name:     "Substring function with length",
+			fn:       Substring(F("s.name"), 1, 3),
+			wantExpr: "SUBSTRING(s.name, 1, 3)",
+			wantKind: kinds.String,
+		},
+		{
+			name:     "Substring function without length",
+			fn:       Substring(F("name"), 2),
+			wantExpr: "SUBSTRING(name, 2)",
+			wantKind: kinds.String,
+		},
+		{
+			name:     "Concat function with escaped literal",
+			fn:       Concat(F("first"), " O'", F("last")),
+			wantExpr: "CONCAT(first, ' O''', last)",
+			wantKind: kinds.String,
+		},
+		{
+			name:     "ConcatWS function",
+			fn:       ConcatWS("-", F("a"), F("b")),
+			wantExpr: "CONCAT_WS('-', a, b)",
+			wantKind: kinds.String,
+		},
+		{
+			name:     "Len function",
+			fn:       Len(F("name")),
+			wantExpr: "LEN(name)",
+			wantKind: kinds.Int,
+		},
+		{
+			name:     "Split function",
+			fn:       Split(F("tags"), ","),
+			wantExpr: "SPLIT(tags, ',')",
+			wantKind: kinds.ArrString,
+		},
+		{
+			name:     "Round function",
+			fn:       Round(F("price")),
+			wantExpr: "ROUND(price)",
+			wantKind: kinds.BigInt,
+		},
+		{
+			name:     "RoundTo function",
+			fn:       RoundTo(F("price"), 2),
+			wantExpr: "ROUND(price, 2)",
+			wantKind: kinds.Double,
+		},
+		{
+			name:     "Abs function of column",
+			fn:       Abs(F("delta")),
+			wantExpr: "ABS(delta)",
+			wantKind: 0,
+		},
+		{
+			name:     "Abs function of typed function",
+			fn:       Abs(Len(F("name"))),
+			wantExpr: "ABS(LEN(name))",
+			wantKind: kinds.Int,
+		},
+		{
+			name:     "Random function",
+			fn:       Random(),
+			wantExpr: "RANDOM()",
+			wantKind: kinds.Double,
+		},
+		{
+			name:     "FromUnixtime function",
+			fn:       FromUnixtime(F("ts")),
+			wantExpr: "FROM_UNIXTIME(ts)",
+			wantKind: 0,
+		},
+		{
+			name:     "TimestampToString function",
+			fn:       TimestampToString(F("ROWTIME"), "yyyy-MM-dd"),
+			wantExpr: "TIMESTAMPTOSTRING(ROWTIME, 'yyyy-MM-dd')",
+			wantKind: kinds.String,
+		},
+		{
+			name:     "UnixTimestamp function",
+			fn:       UnixTimestamp(),
+			wantExpr: "UNIX_TIMESTAMP()",
+			wantKind: kinds.BigInt,
+		},
+		{
+			name:     "ExtractJSONField function",
+			fn:       ExtractJSONField(F("payload"), "$.user.id"),
+			wantExpr: "EXTRACTJSONFIELD(payload, '$.user.id')",
+			wantKind: kinds.String,
+		},
+		{
+			name:     "JSONRecords function",
+			fn:       JSONRecords(F("payload")),
+			wantExpr: "JSON_RECORDS(payload)",
+			wantKind: kinds.MapString,
+		},
+		{
+			name:     "Coalesce function",
+			fn:       Coalesce(F("nickname"), F("name"), "anonymous"),
+			wantExpr: "COALESCE(nickname, name, 'anonymous')",
+			wantKind: kinds.String,
+		},
+		{
+			name:     "IfNull function",
+			fn:       IfNull(F("amount"), 0.0),
+			wantExpr: "IFNULL(amount, 0)",
+			wantKind: kinds.Double,
+		},
+		{
+			name:     "NullIf function with nil",
+			fn:       NullIf(F("amount"), nil),
+			wantExpr: "NULLIF(amount, NULL)",
+			wantKind: 0,
+		},
+		{
+			name:     "Function with alias",
+			fn:       Lcase(F("name")).As("lower_name").(ScalarFunction),
+			wantExpr: "LCASE(name) AS lower_name",
+			wantKind: kinds.String,
+		},
+		{
+			name:      "Function with unsupported argument",
+			fn:        Ucase(struct{}{}),
+			expectErr: true,
+		},
+		{
+			name:      "Function with invalid field",
+			fn:        Ucase(F("")),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := tc.fn.Expression()
+			assert.Equal(t, tc.expectErr, err != nil)
+			if !tc.expectErr {
+				assert.Equal(t, tc.wantExpr, expr)
+				assert.Equal(t, tc.wantKind, tc.fn.Kind())
+			}
+		})
+	}
+}
+
+func Test_ScalarFnConditional(t *testing.T) {
+	testcases := []struct {
+		name     string
+		cond     Conditional
+		wantExpr string
+	}{
+		{
+			name:     "Equal",
+			cond:     Ucase(F("name")).As("upper").Equal("JOHN"),
+			wantExpr: "UCASE(name) = 'JOHN'",
+		},
+		{
+			name:     "Greater",
+			cond:     Len(F("name")).Greater(3),
+			wantExpr: "LEN(name) > 3",
+		},
+		{
+			name:     "IsNull",
+			cond:     ExtractJSONField(F("payload"), "$.id").IsNull(),
+			wantExpr: "EXTRACTJSONFIELD(payload, '$.id') IS NULL",
+		},
+		{
+			name:     "In",
+			cond:     Lcase(F("status")).In("new", "done"),
+			wantExpr: "LCASE(status) IN ('new', 'done')",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			expr, err := tc.cond.Expression()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantExpr, expr)
+		})
+	}
+}
+
+func Test_ScalarFnInnerRelations(t *testing.T) {
+	fn := Concat(Ucase(F("s.first")), " ", F("s.last"))
+
+	relations := fn.InnerRelations()
+	columns := make([]string, 0, len(relations))
+	for _, rel := range relations {
+		columns = append(columns, rel.Schema()+"."+rel.Column())
+	}
+
+	assert.Equal(t, []string{"s.first", "s.last"}, columns)
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gulfstream-h/ksql/internal/schema"
+	"github.com/gulfstream-h/ksql/kinds"
 	"github.com/gulfstream-h/ksql/static"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -17,6 +19,7 @@ type (
 
 		aggregated() bool
 		windowed() bool
+		limited() bool
 
 		Returns() schema.LintedFields
 		RelationReport() map[string]schema.LintedFields
@@ -33,9 +36,12 @@ type (
 		Windowed(window WindowExpression) SelectBuilder
 		Having(expressions ...Conditional) SelectBuilder
 		GroupBy(fields ...Field) SelectBuilder
+		PartitionBy(fields ...Field) SelectBuilder
 		OrderBy(expressions ...OrderedExpression) SelectBuilder
 		EmitChanges() SelectBuilder
 		EmitFinal() SelectBuilder
+		Limit(n int) SelectBuilder
+		Reflect(cache *static.Cache) SelectBuilder
 	}
 
 	// Joiner - common contract for all JOIN operations in SELECT statements
@@ -64,9 +70,11 @@ type (
 		ref         Reference
 		emitChanges bool
 		emitFinal   bool
+		limit       int
+		hasLimit    bool
 
 		// relationStorage contains all relations that were added to the select builder
-		// it is used to validate reflection when static.ReflectionFlag is enabled
+		// it is used to validate reflection when reflection is enabled (see Reflect)
 		relationStorage map[string]schema.LintedFields
 
 		// virtualSchemas contains pairs of alias and schema name
@@ -95,11 +103,21 @@ type (
 		havingEx  HavingExpression
 		groupByEx GroupExpression
 		orderByEx OrderByExpression
+
+		partitionByEx PartitionExpression
 	}
 
 	// selectBuilderCtx - context for the select builder
 	selectBuilderCtx struct {
 		err error
+		// reflectionErr is returned only when reflection is enabled
+		reflectionErr error
+
+		// cache is bound to builder with Reflect method.
+		// Unbound builder follows static.ReflectionFlag
+		// and checks fields with static.DefaultCache
+		cache *static.Cache
+		bound bool
 	}
 	// selectBuilderRule - defines a rule for validating select statements
 	selectBuilderRule struct {
@@ -110,6 +128,8 @@ type (
 	returnNameMeta struct {
 		relation string
 		alias    string
+		// kind - type of derived field, if it's known
+		kind kinds.Ktype
 	}
 )
 
@@ -176,6 +196,30 @@ var (
 		description: `EMIT FINAL and EMIT CHANGES cannot be used together`,
 	}
 
+	// 7. LIMIT must be a positive number
+	positiveLimit = selectBuilderRule{
+		ruleFn: func(builder *selectBuilder) (valid bool) {
+			return !(builder.hasLimit && builder.limit <= 0)
+		},
+		description: `LIMIT must be a positive number`,
+	}
+
+	// 8. PARTITION BY can be used only with streams
+	partitionByStream = selectBuilderRule{
+		ruleFn: func(builder *selectBuilder) (valid bool) {
+			return !(builder.ref != STREAM && !builder.partitionByEx.IsEmpty())
+		},
+		description: `PARTITION BY can be used only with streams`,
+	}
+
+	// 9. PARTITION BY and GROUP BY cannot be used together
+	partitionByWithGroupBy = selectBuilderRule{
+		ruleFn: func(builder *selectBuilder) (valid bool) {
+			return !(!builder.partitionByEx.IsEmpty() && !builder.groupByEx.IsEmpty())
+		},
+		description: `PARTITION BY and GROUP BY cannot be used together`,
+	}
+
 	selectRuleSet = []selectBuilderRule{
 		groupByWindowed,
 		havingWithGroupBy,
@@ -183,6 +227,9 @@ var (
 		windowInTable,
 		emitFinalWithTable,
 		emitFinalAndChanges,
+		positiveLimit,
+		partitionByStream,
+		partitionByWithGroupBy,
 	}
 )
 
@@ -206,6 +253,7 @@ func newSelectBuilder() SelectBuilder {
 		havingEx:         NewHavingExpression(),
 		groupByEx:        NewGroupByExpression(),
 		orderByEx:        NewOrderByExpression(),
+		partitionByEx:    NewPartitionByExpression(),
 		relationStorage:  make(map[string]schema.LintedFields),
 		virtualSchemas:   make(map[string]string),
 		virtualColumns:   make(map[string]string),
@@ -244,6 +292,19 @@ func (s *selectBuilder) EmitChanges() SelectBuilder {
 	return s
 }
 
+// Limit sets maximum count of rows returned by query.
+// Push query is completed after n rows are emitted
+func (s *selectBuilder) Limit(n int) SelectBuilder {
+	s.limit = n
+	s.hasLimit = true
+	return s
+}
+
+// limited checks if the select builder has a LIMIT clause
+func (s *selectBuilder) limited() bool {
+	return s.hasLimit
+}
+
 // Ref returns the reference type of the select builder
 func (s *selectBuilder) Ref() Reference {
 	return s.ref
@@ -299,27 +360,24 @@ func (s *selectBuilder) Alias() string {
 func (s *selectBuilder) Select(fields ...Field) SelectBuilder {
 	s.fields = append(s.fields, fields...)
 
-	if static.ReflectionFlag {
-		var (
-			rels []Relational
-			// slice of relation that parsed from derived fields (see Relation interface derived method)
-			// inner relations participate only in reflection report
-			// and do not in return schema reflection check
-			innerRels []Relational
-		)
-
-		for idx := range fields {
-			rels = append(rels, fields[idx])
-			innerRels = append(innerRels, fields[idx].InnerRelations()...)
-		}
+	var (
+		rels []Relational
+		// slice of relation that parsed from derived fields (see Relation interface derived method)
+		// inner relations participate only in reflection report
+		// and do not in return schema reflection check
+		innerRels []Relational
+	)
 
-		for idx := range rels {
-			s.processRelation(rels[idx], true)
-		}
-		for idx := range innerRels {
-			s.processRelation(innerRels[idx], false)
-		}
+	for idx := range fields {
+		rels = append(rels, fields[idx])
+		innerRels = append(innerRels, fields[idx].InnerRelations()...)
+	}
 
+	for idx := range rels {
+		s.processRelation(rels[idx], true)
+	}
+	for idx := range innerRels {
+		s.processRelation(innerRels[idx], false)
 	}
 
 	return s
@@ -383,11 +441,9 @@ func (s *selectBuilder) join(
 	joinType JoinType,
 ) SelectBuilder {
 
-	if static.ReflectionFlag {
-		fields := s.parseSearchFieldsFromCond(on)
-		for idx := range fields {
-			s.addSearchField(fields[idx])
-		}
+	fields := s.parseSearchFieldsFromCond(on)
+	for idx := range fields {
+		s.addSearchField(fields[idx])
 	}
 
 	// append join expression to the select builder
@@ -414,14 +470,12 @@ func (s *selectBuilder) From(from FromExpression) SelectBuilder {
 
 // Having adds HAVING expressions to the select builder
 func (s *selectBuilder) Having(expressions ...Conditional) SelectBuilder {
-	if static.ReflectionFlag {
-		// for every expression try parse Field
-		// and add them to the relation storage
-		for idx := range expressions {
-			fields := s.parseSearchFieldsFromCond(expressions[idx])
-			for idx := range fields {
-				s.addSearchField(fields[idx])
-			}
+	// for every expression try parse Field
+	// and add them to the relation storage
+	for idx := range expressions {
+		fields := s.parseSearchFieldsFromCond(expressions[idx])
+		for idx := range fields {
+			s.addSearchField(fields[idx])
 		}
 	}
 	s.havingEx = s.havingEx.Having(expressions...)
@@ -430,33 +484,46 @@ func (s *selectBuilder) Having(expressions ...Conditional) SelectBuilder {
 
 // GroupBy adds GROUP BY expressions to the select builder
 func (s *selectBuilder) GroupBy(fields ...Field) SelectBuilder {
-	if static.ReflectionFlag {
-		for idx := range fields {
-			// parse relation name from the field
-			relationName := s.parseRelationName(fields[idx])
-			if len(relationName) == 0 {
-				continue
-			}
-			s.addSearchField(schema.SearchField{
-				Name:     fields[idx].Column(),
-				Relation: relationName,
-			})
+	for idx := range fields {
+		// parse relation name from the field
+		relationName := s.parseRelationName(fields[idx])
+		if len(relationName) == 0 {
+			continue
 		}
+		s.addSearchField(schema.SearchField{
+			Name:     fields[idx].Column(),
+			Relation: relationName,
+		})
 	}
 	s.groupByEx = s.groupByEx.GroupBy(fields...)
 	return s
 }
 
+// PartitionBy adds PARTITION BY expressions to the select builder.
+// Stream is re-keyed by provided fields
+func (s *selectBuilder) PartitionBy(fields ...Field) SelectBuilder {
+	for idx := range fields {
+		relationName := s.parseRelationName(fields[idx])
+		if len(relationName) == 0 {
+			continue
+		}
+		s.addSearchField(schema.SearchField{
+			Name:     fields[idx].Column(),
+			Relation: relationName,
+		})
+	}
+	s.partitionByEx = s.partitionByEx.PartitionBy(fields...)
+	return s
+}
+
 // Where adds WHERE expressions to the select builder
 func (s *selectBuilder) Where(expressions ...Conditional) SelectBuilder {
-	if static.ReflectionFlag {
-		// for every expression try parse Field
-		// and add them to the relation storage
-		for idx := range expressions {
-			fields := s.parseSearchFieldsFromCond(expressions[idx])
-			for idx := range fields {
-				s.addSearchField(fields[idx])
-			}
+	// for every expression try parse Field
+	// and add them to the relation storage
+	for idx := range expressions {
+		fields := s.parseSearchFieldsFromCond(expressions[idx])
+		for idx := range fields {
+			s.addFilterField(fields[idx])
 		}
 	}
 	s.whereEx = s.whereEx.Where(expressions...)
@@ -467,10 +534,43 @@ func (s *selectBuilder) Where(expressions ...Conditional) SelectBuilder {
 func (s *selectBuilder) WithCTE(
 	inner SelectBuilder,
 ) SelectBuilder {
+	if s.ctx.bound {
+		inner.Reflect(s.ctx.cache)
+	}
 	s.with = append(s.with, inner)
 	return s
 }
 
+// Reflect binds the select builder to reflection cache of client.
+// Fields and conditionals are checked with the cache, nil
+// cache disables reflection. Unbound builder follows
+// process-wide static.ReflectionFlag and static.DefaultCache
+func (s *selectBuilder) Reflect(cache *static.Cache) SelectBuilder {
+	s.ctx.cache = cache
+	s.ctx.bound = true
+
+	for idx := range s.with {
+		s.with[idx].Reflect(cache)
+	}
+	return s
+}
+
+// reflection checks if the select builder is checked with reflection cache
+func (s *selectBuilder) reflection() bool {
+	if s.ctx.bound {
+		return s.ctx.cache != nil
+	}
+	return static.ReflectionFlag
+}
+
+// cache returns reflection cache, which the select builder is checked with
+func (s *selectBuilder) cache() *static.Cache {
+	if s.ctx.bound {
+		return s.ctx.cache
+	}
+	return static.DefaultCache
+}
+
 // WithMeta adds metadata to the select builder
 func (s *selectBuilder) WithMeta(
 	with Metadata,
@@ -481,22 +581,20 @@ func (s *selectBuilder) WithMeta(
 
 // OrderBy adds ORDER BY expressions to the select builder
 func (s *selectBuilder) OrderBy(expressions ...OrderedExpression) SelectBuilder {
-	if static.ReflectionFlag {
-		for idx := range expressions {
-			field := expressions[idx].Field()
-			if field == nil {
-				continue
-			}
+	for idx := range expressions {
+		field := expressions[idx].Field()
+		if field == nil {
+			continue
+		}
 
-			relationName := s.parseRelationName(field)
-			if len(relationName) == 0 {
-				continue
-			}
-			s.addSearchField(schema.SearchField{
-				Name:     field.Column(),
-				Relation: relationName,
-			})
+		relationName := s.parseRelationName(field)
+		if len(relationName) == 0 {
+			continue
 		}
+		s.addSearchField(schema.SearchField{
+			Name:     field.Column(),
+			Relation: relationName,
+		})
 	}
 	s.orderByEx.OrderBy(expressions...)
 	return s
@@ -514,6 +612,10 @@ func (s *selectBuilder) Expression() (string, error) {
 		return "", fmt.Errorf("select builder error: %w", s.ctx.err)
 	}
 
+	if s.ctx.reflectionErr != nil && s.reflection() {
+		return "", fmt.Errorf("select builder error: %w", s.ctx.reflectionErr)
+	}
+
 	// validate reference
 	switch s.ref {
 	case TABLE, STREAM, TOPIC:
@@ -528,6 +630,13 @@ func (s *selectBuilder) Expression() (string, error) {
 		}
 	}
 
+	// check operands of conditionals with cached relations
+	if s.reflection() {
+		if err := s.checkKinds(); err != nil {
+			return "", fmt.Errorf("invalid select builder: %w", err)
+		}
+	}
+
 	// write CTEs recursively
 	if len(s.with) > 0 {
 		for i := range s.with {
@@ -603,6 +712,16 @@ func (s *selectBuilder) Expression() (string, error) {
 		builder.WriteString(whereString)
 	}
 
+	if !s.partitionByEx.IsEmpty() {
+		partitionByString, err := s.partitionByEx.Expression()
+		if err != nil {
+			return "", fmt.Errorf("PARTITION BY expression: %w", err)
+		}
+
+		builder.WriteString(" ")
+		builder.WriteString(partitionByString)
+	}
+
 	if s.windowEx != nil {
 		windowString, err := s.windowEx.Expression()
 		if err != nil {
@@ -649,6 +768,12 @@ func (s *selectBuilder) Expression() (string, error) {
 	if s.emitFinal {
 		builder.WriteString(" EMIT FINAL")
 	}
+
+	// LIMIT follows EMIT clause
+	if s.hasLimit {
+		builder.WriteString(" LIMIT ")
+		builder.WriteString(strconv.Itoa(s.limit))
+	}
 	metaExpression := s.meta.Expression()
 	if len(metaExpression) > 0 {
 		builder.WriteString(" " + s.meta.Expression())
@@ -676,6 +801,7 @@ func (s *selectBuilder) Returns() schema.LintedFields {
 				result.Set(schema.SearchField{
 					Name:     meta.alias,
 					Relation: "",
+					Kind:     meta.kind,
 				})
 			}
 
@@ -700,13 +826,83 @@ func (s *selectBuilder) Returns() schema.LintedFields {
 		}
 	}
 
+	// re-keyed stream has partitioning columns as its key
+	for _, f := range s.partitionByEx.PartitionedFields() {
+		key, ok := result.Get(f.Column())
+		if !ok {
+			if key, ok = s.lookupField(f); !ok {
+				continue
+			}
+		}
+
+		key.IsPrimary = true
+		result.Set(key)
+	}
+
 	return result
 }
 
+// checkKinds compares types of conditionals operands in WHERE,
+// HAVING and JOIN clauses with columns of cached relations
+func (s *selectBuilder) checkKinds() error {
+	var (
+		resolve = cachedKinds(s.cache(), s.relationOf)
+		conds   = append(s.whereEx.Conditionals(), s.havingEx.Conditionals()...)
+	)
+
+	for _, join := range s.joinExs {
+		if join != nil && join.On() != nil {
+			conds = append(conds, join.On())
+		}
+	}
+
+	for _, cond := range conds {
+		if err := checkKinds(cond, resolve); err != nil {
+			return fmt.Errorf("type mismatch: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// relationOf returns real name of relation, which owns the field.
+// Fields without schema belong to relation from FROM clause
+func (s *selectBuilder) relationOf(f Field) string {
+	relation := f.Schema()
+	if len(relation) == 0 {
+		relation = s.fromEx.Schema()
+	}
+
+	if realRel, ok := s.virtualSchemas[relation]; ok && realRel != defaultSchemaName {
+		relation = realRel
+	}
+
+	return relation
+}
+
+// lookupField searches field in relation storage by its real relation name
+func (s *selectBuilder) lookupField(f Field) (schema.SearchField, bool) {
+	relation := f.Schema()
+	if len(relation) == 0 {
+		relation = defaultSchemaName
+	}
+
+	if realRel, ok := s.virtualSchemas[relation]; ok {
+		relation = realRel
+	}
+
+	rel, ok := s.relationStorage[relation]
+	if !ok {
+		return schema.SearchField{}, false
+	}
+
+	return rel.Get(f.Column())
+}
+
 // RelationReport - sets real relation names to aliased fields
-// if the reflection flag is enabled. Then it returns all processed fields
+// if reflection is enabled. Then it returns all processed fields
 func (s *selectBuilder) RelationReport() map[string]schema.LintedFields {
-	if static.ReflectionFlag {
+	if s.reflection() {
 		s.buildRelationReport()
 		return s.relationStorage
 	}
@@ -726,9 +922,13 @@ func (s *selectBuilder) buildRelationReport() {
 			// if we have a relation with the alias,
 			// we should replace it with the real schema name
 			// and remove the alias from the relation storage
-			if _, exists := s.relationStorage[alias]; exists {
-				s.relationStorage[schemaName] = s.relationStorage[alias]
-				for _, v := range s.relationStorage[schemaName].Map() {
+			if aliased, exists := s.relationStorage[alias]; exists {
+				// relation may already hold fields,
+				// which refer to it by real name
+				if s.relationStorage[schemaName] == nil {
+					s.relationStorage[schemaName] = schema.NewLintedFields()
+				}
+				for _, v := range aliased.Map() {
 					v.Relation = schemaName
 					s.relationStorage[schemaName].Set(v)
 				}
@@ -777,7 +977,7 @@ func (s *selectBuilder) processRelation(rel Relational, returnCheck bool) {
 	if rel.derived() {
 
 		if len(rel.Alias()) == 0 {
-			s.ctx.err = fmt.Errorf("derived field should have an alias")
+			s.ctx.reflectionErr = fmt.Errorf("derived field should have an alias")
 			return
 		}
 
@@ -786,6 +986,10 @@ func (s *selectBuilder) processRelation(rel Relational, returnCheck bool) {
 			alias:    rel.Alias(),
 		}
 
+		if t, ok := rel.(typed); ok {
+			meta.kind = t.Kind()
+		}
+
 		sl, _ := s.returnTypeMapper[meta.alias]
 		sl = append(sl, meta)
 		s.returnTypeMapper[meta.alias] = sl
@@ -854,6 +1058,22 @@ func (s *selectBuilder) addSearchField(
 	s.relationStorage[field.Relation].Set(field)
 }
 
+// addFilterField adds field of WHERE clause to the relation storage.
+// WHERE can't refer to aliases of selected columns, so its
+// fields always belong to relations of FROM and JOIN clauses
+func (s *selectBuilder) addFilterField(
+	field schema.SearchField,
+) {
+
+	if realRel, ok := s.virtualSchemas[field.Relation]; ok {
+		field.Relation = realRel
+	}
+	if s.relationStorage[field.Relation] == nil {
+		s.relationStorage[field.Relation] = schema.NewLintedFields()
+	}
+	s.relationStorage[field.Relation].Set(field)
+}
+
 // parseRelationName parses the relation name from the field
 func (s *selectBuilder) parseRelationName(f Relational) string {
 
@@ -888,15 +1108,7 @@ func (s *selectBuilder) parseSearchFieldsFromCond(
 			continue
 		}
 
-		relationName := s.parseRelationName(f)
-		if len(relationName) == 0 {
-			continue
-		}
-
-		result = append(result, schema.SearchField{
-			Name:     f.Column(),
-			Relation: relationName,
-		})
+		result = append(result, s.parseSearchFields(f)...)
 	}
 
 	for _, right := range cond.Right() {
@@ -907,16 +1119,40 @@ func (s *selectBuilder) parseSearchFieldsFromCond(
 		// if the right side of the join condition is a field,
 		// we should parse the relation name from it
 		if rightField, ok := right.(Field); ok {
-			relationName := s.parseRelationName(rightField)
-			if len(relationName) == 0 {
-				continue
-			}
-			result = append(result, schema.SearchField{
-				Name:     rightField.Column(),
-				Relation: relationName,
-			})
+			result = append(result, s.parseSearchFields(rightField)...)
 		}
 	}
 
 	return result
 }
+
+// parseSearchFields returns search field of column. Derived fields,
+// like functions, are replaced with columns passed to them
+func (s *selectBuilder) parseSearchFields(f Field) []schema.SearchField {
+	var (
+		rels   = []Relational{f}
+		result []schema.SearchField
+	)
+
+	if f.derived() {
+		rels = f.InnerRelations()
+	}
+
+	for _, rel := range rels {
+		if rel.derived() {
+			continue
+		}
+
+		relationName := s.parseRelationName(rel)
+		if len(relationName) == 0 {
+			continue
+		}
+
+		result = append(result, schema.SearchField{
+			Name:     rel.Column(),
+			Relation: relationName,
+		})
+	}
+
+	return result
+}
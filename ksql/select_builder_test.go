@@ -3,6 +3,7 @@ package ksql
 import (
 	"fmt"
 	"github.com/gulfstream-h/ksql/internal/schema"
+	"github.com/gulfstream-h/ksql/kinds"
 	"github.com/gulfstream-h/ksql/static"
 	"github.com/stretchr/testify/assert"
 	"regexp"
@@ -675,6 +676,69 @@ func Test_SelectBuilder(t *testing.T) {
 			expected:  "SELECT seller_id AS seller_id, ( ( SUM(price) * quantity ) * 0.05 ) AS salary FROM purchases_stream GROUP BY seller_id EMIT CHANGES;",
 			expectErr: false,
 		},
+		{
+			name: "SELECT with PARTITION BY on stream",
+			selectSQL: Select(F("stream.column1"), F("stream.column2")).
+				From(Schema("stream", STREAM)).
+				Where(F("stream.column1").Greater(1)).
+				PartitionBy(F("stream.column2")).
+				EmitChanges(),
+			expected:  "SELECT stream.column1, stream.column2 FROM stream WHERE stream.column1 > 1 PARTITION BY stream.column2 EMIT CHANGES;",
+			expectErr: false,
+		},
+		{
+			name: "SELECT with PARTITION BY on table (invalid)",
+			selectSQL: Select(F("table.column1")).
+				From(Schema("table", TABLE)).
+				PartitionBy(F("table.column1")),
+			expected:  "",
+			expectErr: true,
+		},
+		{
+			name: "SELECT with PARTITION BY and GROUP BY (invalid)",
+			selectSQL: Select(F("stream.column1")).
+				From(Schema("stream", STREAM)).
+				PartitionBy(F("stream.column1")).
+				GroupBy(F("stream.column1")).
+				EmitChanges(),
+			expected:  "",
+			expectErr: true,
+		},
+		{
+			name: "SELECT with LIMIT on table",
+			selectSQL: Select(F("table.column1")).
+				From(Schema("table", TABLE)).
+				Where(F("table.column1").Greater(1)).
+				Limit(10),
+			expected:  "SELECT table.column1 FROM table WHERE table.column1 > 1 LIMIT 10;",
+			expectErr: false,
+		},
+		{
+			name: "SELECT with EMIT CHANGES and LIMIT on stream",
+			selectSQL: Select(F("stream.column1")).
+				From(Schema("stream", STREAM)).
+				Limit(5).
+				EmitChanges(),
+			expected:  "SELECT stream.column1 FROM stream EMIT CHANGES LIMIT 5;",
+			expectErr: false,
+		},
+		{
+			name: "SELECT with zero LIMIT (invalid)",
+			selectSQL: Select(F("stream.column1")).
+				From(Schema("stream", STREAM)).
+				EmitChanges().
+				Limit(0),
+			expected:  "",
+			expectErr: true,
+		},
+		{
+			name: "SELECT with negative LIMIT (invalid)",
+			selectSQL: Select(F("table.column1")).
+				From(Schema("table", TABLE)).
+				Limit(-1),
+			expected:  "",
+			expectErr: true,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -785,6 +849,35 @@ func Test_SelectBuilderRelationStorage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "SELECT with PARTITION BY",
+			builder: Select(F("stream.column1"), F("stream.column2")).
+				From(Schema("stream", STREAM)).
+				PartitionBy(F("stream.column2")),
+			expectedRelationStorage: map[string]map[string]schema.SearchField{
+				"stream": {
+					"column1": schema.SearchField{
+						Name:     "column1",
+						Relation: "stream",
+					},
+					"column2": schema.SearchField{
+						Name:     "column2",
+						Relation: "stream",
+					},
+				},
+			},
+			expectedReturn: map[string]schema.SearchField{
+				"column1": {
+					Name:     "column1",
+					Relation: "stream",
+				},
+				"column2": {
+					Name:      "column2",
+					Relation:  "stream",
+					IsPrimary: true,
+				},
+			},
+		},
 		{
 			name: "SELECT with GROUP BY",
 			builder: Select(F("table.column1"), F("table.column2")).
@@ -992,6 +1085,110 @@ func Test_SelectBuilderRelationStorage(t *testing.T) {
 				"max_amount":        {Name: "max_amount", Relation: ""},
 			},
 		},
+		{
+			name: "SELECT with scalar functions",
+			builder: Select(
+				F("users.id"),
+				Ucase(F("users.name")).As("upper_name"),
+				Len(Trim(F("users.name"))).As("name_length"),
+				Coalesce(F("users.nickname"), "anonymous").As("nickname"),
+				RoundTo(F("users.score"), 2).As("score")).
+				From(Schema("users", STREAM)).
+				Where(Lcase(F("users.email")).Equal("a@b.c")),
+			expectedRelationStorage: map[string]map[string]schema.SearchField{
+				"users": {
+					"id":       {Name: "id", Relation: "users"},
+					"name":     {Name: "name", Relation: "users"},
+					"nickname": {Name: "nickname", Relation: "users"},
+					"score":    {Name: "score", Relation: "users"},
+					"email":    {Name: "email", Relation: "users"},
+				},
+			},
+			expectedReturn: map[string]schema.SearchField{
+				"id":          {Name: "id", Relation: "users"},
+				"upper_name":  {Name: "upper_name", Kind: kinds.String},
+				"name_length": {Name: "name_length", Kind: kinds.Int},
+				"nickname":    {Name: "nickname", Kind: kinds.String},
+				"score":       {Name: "score", Kind: kinds.Double},
+			},
+		},
+		{
+			name: "SELECT with lambda functions",
+			builder: Select(
+				F("orders.id"),
+				Transform(F("orders.prices"), func(x Field) any {
+					return Mul(x, F("orders.rate"))
+				}).As("converted"),
+				Reduce(F("orders.prices"), 0.0, func(s, x Field) any {
+					return Add(s, x)
+				}).As("total")).
+				From(Schema("orders", STREAM)),
+			expectedRelationStorage: map[string]map[string]schema.SearchField{
+				"orders": {
+					"id":     {Name: "id", Relation: "orders"},
+					"prices": {Name: "prices", Relation: "orders"},
+					"rate":   {Name: "rate", Relation: "orders"},
+				},
+			},
+			expectedReturn: map[string]schema.SearchField{
+				"id":        {Name: "id", Relation: "orders"},
+				"converted": {Name: "converted"},
+				"total":     {Name: "total", Kind: kinds.Double},
+			},
+		},
+		{
+			name: "SELECT with field paths",
+			builder: Select(
+				F("users.id"),
+				F("users.address").Deref("city").As("city"),
+				F("users.tags").Index(1).As("first_tag")).
+				From(Schema("users", STREAM)).
+				Where(F("users.attrs").Key("role").Equal("admin")),
+			expectedRelationStorage: map[string]map[string]schema.SearchField{
+				"users": {
+					"id":      {Name: "id", Relation: "users"},
+					"address": {Name: "address", Relation: "users"},
+					"tags":    {Name: "tags", Relation: "users"},
+					"attrs":   {Name: "attrs", Relation: "users"},
+				},
+			},
+			expectedReturn: map[string]schema.SearchField{
+				"id":        {Name: "id", Relation: "users"},
+				"city":      {Name: "city"},
+				"first_tag": {Name: "first_tag"},
+			},
+		},
+		{
+			name: "SELECT with WHERE after FROM",
+			builder: Select(F("id")).
+				From(Schema("orders", STREAM)).
+				Where(F("amount").Greater(10), F("orders.status").Equal("NEW")),
+			expectedRelationStorage: map[string]map[string]schema.SearchField{
+				"orders": {
+					"id":     {Name: "id", Relation: "orders"},
+					"amount": {Name: "amount", Relation: "orders"},
+					"status": {Name: "status", Relation: "orders"},
+				},
+			},
+			expectedReturn: map[string]schema.SearchField{
+				"id": {Name: "id", Relation: "orders"},
+			},
+		},
+		{
+			name: "SELECT with WHERE on aliased relation",
+			builder: Select(F("o.id")).
+				From(Schema("orders", STREAM).As("o")).
+				Where(F("o.amount").Greater(10)),
+			expectedRelationStorage: map[string]map[string]schema.SearchField{
+				"orders": {
+					"id":     {Name: "id", Relation: "orders"},
+					"amount": {Name: "amount", Relation: "orders"},
+				},
+			},
+			expectedReturn: map[string]schema.SearchField{
+				"id": {Name: "id", Relation: "orders"},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -3,13 +3,12 @@ package migrations
 import (
 	"context"
 	"errors"
+	"github.com/gulfstream-h/ksql/database"
 	libErrors "github.com/gulfstream-h/ksql/errors"
-	"github.com/gulfstream-h/ksql/internal/kernel/network"
 	"github.com/gulfstream-h/ksql/kinds"
 	"github.com/gulfstream-h/ksql/shared"
 	"github.com/gulfstream-h/ksql/streams"
 	"log/slog"
-	"net/http"
 	"time"
 )
 
@@ -21,6 +20,8 @@ const (
 // iteration with embedded ksql system stream
 type ksqlController struct {
 	host   string
+	client *database.Client
+	logger *slog.Logger
 	stream *streams.Stream[migrationRelation]
 }
 
@@ -31,12 +32,23 @@ type (
 	}
 )
 
-func newKsqlController(host string) controller {
+func newKsqlController(
+	host string,
+	client *database.Client,
+	logger *slog.Logger,
+) controller {
 	return &ksqlController{
-		host: host,
+		host:   host,
+		client: client,
+		logger: logger,
 	}
 }
 
+// log - returns configured logger or logger of client
+func (k *ksqlController) log() *slog.Logger {
+	return loggerOf(k.logger, k.client)
+}
+
 func (ctrl *ksqlController) createSystemRelations(
 	ctx context.Context) (*streams.Stream[migrationRelation], error) {
 
@@ -54,7 +66,8 @@ func (ctrl *ksqlController) createSystemRelations(
 	migStream, err := streams.CreateStream[migrationRelation](
 		ctx,
 		systemStreamName,
-		settings)
+		settings,
+		database.WithClient(ctrl.client))
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +76,7 @@ func (ctrl *ksqlController) createSystemRelations(
 		"VERSION":    time.Time{}.Format(time.RFC3339),
 		"UPDATED_AT": time.Time{}.Format(time.RFC3339),
 	}); err != nil {
-		slog.Debug("cannot insert default values to migration stream")
+		ctrl.log().Debug("cannot insert default values to migration stream")
 
 		return nil, err
 	}
@@ -75,10 +88,11 @@ func (k *ksqlController) GetLatestVersion(ctx context.Context) (time.Time, error
 	migrationStream, err := streams.GetStream[migrationRelation](
 		ctx,
 		systemStreamName,
+		database.WithClient(k.client),
 	)
 
 	if errors.Is(err, libErrors.ErrStreamDoesNotExist) {
-		slog.Debug("migration table doesnt exist")
+		k.log().Debug("migration table doesnt exist")
 		migrationStream, err = k.createSystemRelations(ctx)
 		return time.Time{}, err
 	}
@@ -94,7 +108,7 @@ func (k *ksqlController) GetLatestVersion(ctx context.Context) (time.Time, error
 		return time.Time{}, err
 	}
 
-	slog.Info("selected", "version", msg)
+	k.log().Debug("selected", "version", msg)
 
 	v, err := time.Parse(time.RFC3339, msg.Version)
 	if err != nil {
@@ -109,25 +123,27 @@ func (k *ksqlController) UpgradeWithMigration(
 	version time.Time,
 	query string) error {
 
-	stream, err := streams.GetStream[migrationRelation](ctx, systemStreamName)
+	stream, err := streams.GetStream[migrationRelation](
+		ctx,
+		systemStreamName,
+		database.WithClient(k.client),
+	)
 	if err != nil {
-		slog.Debug("cannot get migration stream",
+		k.log().Debug("cannot get migration stream",
 			"error", err.Error())
 
 		return ErrMigrationServiceNotAvailable
 	}
 
-	resp, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query, network.ShortPolling{},
-	)
+	resp, err := database.ClientOf(
+		database.WithClient(k.client),
+	).Perform(ctx, query)
 	if err != nil {
 		return errors.Join(ErrMigrationServiceNotAvailable, err)
 	}
 
 	for v := range resp {
-		slog.Info("mig resp", "formatted", string(v))
+		k.log().Debug("migration response received", slog.Int("size", len(v)))
 	}
 
 	fields := map[string]any{
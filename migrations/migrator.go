@@ -3,6 +3,8 @@ package migrations
 import (
 	"context"
 	"errors"
+	"github.com/gulfstream-h/ksql/database"
+	"github.com/gulfstream-h/ksql/internal/util"
 	"log/slog"
 	"math"
 	"os"
@@ -14,19 +16,66 @@ import (
 
 type migrationPath string
 
+// Option - configures migration orchestrator
+type Option func(m *migrator)
+
+// WithClient - runs migrations on provided client
+// instead of default one
+func WithClient(client *database.Client) Option {
+	return func(m *migrator) {
+		m.client = client
+	}
+}
+
+// WithLogger - routes migration logs to provided logger
+// instead of logger of client
+func WithLogger(logger *slog.Logger) Option {
+	return func(m *migrator) {
+		m.logger = logger
+	}
+}
+
 // migrator - orchestrate migration actions
 type migrator struct {
 	ctrl            controller
 	reflectionCheck bool
 	migrationPath   string
+	client          *database.Client
+	logger          *slog.Logger
 }
 
 // New - creates new migration orchestrator
-func New(host string, migrationPath migrationPath) Migrator {
-	return &migrator{
+func New(
+	host string,
+	migrationPath migrationPath,
+	opts ...Option,
+) Migrator {
+
+	m := &migrator{
 		migrationPath: string(migrationPath),
-		ctrl:          newKsqlController(host),
 	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	m.ctrl = newKsqlController(host, m.client, m.logger)
+
+	return m
+}
+
+// log - returns configured logger or logger of client
+func (m *migrator) log() *slog.Logger {
+	return loggerOf(m.logger, m.client)
+}
+
+// loggerOf - returns configured logger or logger of client
+func loggerOf(logger *slog.Logger, client *database.Client) *slog.Logger {
+	if logger != nil {
+		return logger
+	}
+
+	return database.ClientOf(database.WithClient(client)).Logger()
 }
 
 // GenPath - returns function for building migration absolute path
@@ -80,7 +129,7 @@ func (m *migrator) AutoMigrate(ctx context.Context) error {
 
 		query = strings.Replace(query, "\n", "", -1)
 
-		slog.Info("query", "parsed", query)
+		m.log().Debug("migration query", slog.Any("statement", util.Statement(query)))
 
 		if err = m.ctrl.UpgradeWithMigration(
 			ctx,
@@ -98,27 +147,27 @@ func (m *migrator) AutoMigrate(ctx context.Context) error {
 func (m *migrator) Up(filename string) error {
 	currentVersion, err := m.ctrl.GetLatestVersion(context.TODO())
 	if err != nil {
-		slog.Debug("cannot get actual version")
+		m.log().Debug("cannot get actual version")
 		return err
 	}
 
-	slog.Info("current version", "formatted", currentVersion)
+	m.log().Info("current version", "formatted", currentVersion)
 
 	filenameSegments := strings.Split(filename, "_")
 	if len(filenameSegments) < 2 {
-		slog.Debug("cannot split filename")
+		m.log().Debug("cannot split filename")
 		return err
 	}
 
 	versionInt, err := strconv.Atoi(filenameSegments[0])
 	if err != nil {
-		slog.Debug("cannot convert version to time")
+		m.log().Debug("cannot convert version to time")
 		return errors.Join(ErrMalformedMigrationFile, err)
 	}
 
 	version := time.Unix(int64(versionInt), 0)
 
-	slog.Info("version", "formatted", version)
+	m.log().Info("version", "formatted", version)
 
 	if version.Before(currentVersion) {
 		return errors.New("cannot up migration, cuz current version is ahead")
@@ -135,7 +184,7 @@ func (m *migrator) Up(filename string) error {
 
 	query = strings.Replace(query, "\n", "", -1)
 
-	slog.Info("query", "parsed", query)
+	m.log().Debug("migration query", slog.Any("statement", util.Statement(query)))
 
 	if err = m.ctrl.UpgradeWithMigration(context.TODO(), version, query); err != nil {
 		return err
@@ -174,7 +223,7 @@ func (m *migrator) Down(filename string) error {
 
 	query = strings.Replace(query, "\n", "", -1)
 
-	slog.Info("query", "parsed", query)
+	m.log().Debug("migration query", slog.Any("statement", util.Statement(query)))
 
 	lastVersion := m.FindPrecedingMigration(int64(versionInt))
 
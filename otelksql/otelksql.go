@@ -0,0 +1,249 @@
+// Package otelksql - OpenTelemetry adapter for client interceptors.
+// It emits span and metrics for every statement. Statement text
+// is recorded with literals redacted, unless raw text is enabled:
+//
+//	cfg := config.New(url, timeout, reflection,
+//		config.WithInterceptors(otelksql.New()),
+//	)
+package otelksql
+
+import (
+	"context"
+	"errors"
+	ksqlErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/gulfstream-h/ksql/shared"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+const (
+	// instrumentationName - name of tracer and meter
+	instrumentationName = "github.com/gulfstream-h/ksql/otelksql"
+	// dbSystem - database identifier for db.system.name attribute
+	dbSystem = "ksqldb"
+)
+
+type (
+	// Interceptor - traces statements and records their metrics
+	Interceptor struct {
+		tracer     trace.Tracer
+		propagator propagation.TextMapPropagator
+		duration   metric.Float64Histogram
+		rows       metric.Int64Counter
+		statement  bool
+		raw        bool
+	}
+
+	// Option - configures interceptor
+	Option func(cfg *settings)
+
+	// settings - accumulated interceptor options
+	settings struct {
+		tracerProvider trace.TracerProvider
+		meterProvider  metric.MeterProvider
+		propagator     propagation.TextMapPropagator
+		statement      bool
+		raw            bool
+	}
+)
+
+// WithTracerProvider - replaces global tracer provider
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(cfg *settings) {
+		cfg.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider - replaces global meter provider
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(cfg *settings) {
+		cfg.meterProvider = provider
+	}
+}
+
+// WithPropagator - replaces global propagator,
+// that injects trace context into request headers
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(cfg *settings) {
+		cfg.propagator = propagator
+	}
+}
+
+// WithoutStatementText - omits db.query.text attribute,
+// so even redacted statements are not exported
+func WithoutStatementText() Option {
+	return func(cfg *settings) {
+		cfg.statement = false
+	}
+}
+
+// WithRawStatementText - records db.query.text with literals.
+// Inserted and filtered values, e.g. credentials or personal
+// data, are exported to tracing backend, so it's for debugging only
+func WithRawStatementText() Option {
+	return func(cfg *settings) {
+		cfg.raw = true
+	}
+}
+
+// New - creates interceptor with global
+// OpenTelemetry providers, unless options are passed
+func New(opts ...Option) *Interceptor {
+	cfg := settings{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+		statement:      true,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	// instruments creation fails only on invalid names,
+	// in such case no-op instruments are returned
+	duration, err := meter.Float64Histogram(
+		"db.client.operation.duration",
+		metric.WithDescription("Duration of ksqlDB statements"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	rows, err := meter.Int64Counter(
+		"db.client.response.returned_rows",
+		metric.WithDescription("Rows received by ksqlDB queries"),
+		metric.WithUnit("{row}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return &Interceptor{
+		tracer:     cfg.tracerProvider.Tracer(instrumentationName),
+		propagator: cfg.propagator,
+		duration:   duration,
+		rows:       rows,
+		statement:  cfg.statement,
+		raw:        cfg.raw,
+	}
+}
+
+// Before - starts client span and propagates it to server
+func (i *Interceptor) Before(
+	ctx context.Context,
+	stmt *shared.Statement,
+) context.Context {
+
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system.name", dbSystem),
+		attribute.String("db.operation.name", operation(stmt)),
+		attribute.String("server.address", stmt.Host),
+		attribute.String("url.path", stmt.Route),
+	}
+
+	if i.statement && len(stmt.Query) != 0 {
+		text := stmt.RedactedQuery()
+		if i.raw {
+			text = stmt.Query
+		}
+
+		attrs = append(attrs, attribute.String("db.query.text", text))
+	}
+
+	ctx, _ = i.tracer.Start(
+		ctx,
+		operation(stmt),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(stmt.Start),
+		trace.WithAttributes(attrs...),
+	)
+
+	i.propagator.Inject(ctx, propagation.HeaderCarrier(stmt.Header))
+
+	return ctx
+}
+
+// After - finishes span and records metrics
+func (i *Interceptor) After(
+	ctx context.Context,
+	stmt *shared.Statement,
+	outcome shared.Outcome,
+) {
+
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system.name", dbSystem),
+		attribute.String("db.operation.name", operation(stmt)),
+		attribute.String("server.address", stmt.Host),
+	}
+
+	if outcome.Status != 0 {
+		attrs = append(attrs, attribute.Int("http.response.status_code", outcome.Status))
+	}
+
+	// cancellation is the ordinary way to stop push query
+	failed := outcome.Err != nil && !errors.Is(outcome.Err, context.Canceled)
+	if failed {
+		attrs = append(attrs, attribute.String("error.type", errorType(outcome.Err)))
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attrs...)
+	span.SetAttributes(attribute.Int64("db.response.returned_rows", outcome.Rows))
+
+	if failed {
+		span.RecordError(outcome.Err)
+		span.SetStatus(codes.Error, outcome.Err.Error())
+	}
+
+	span.End(trace.WithTimestamp(stmt.Start.Add(outcome.Duration)))
+
+	set := metric.WithAttributes(attrs...)
+
+	i.duration.Record(ctx, outcome.Duration.Seconds(), set)
+	if outcome.Rows != 0 {
+		i.rows.Add(ctx, outcome.Rows, set)
+	}
+}
+
+// operation - statement keyword or route for service requests
+func operation(stmt *shared.Statement) string {
+	fields := strings.Fields(stmt.Query)
+	if len(fields) == 0 {
+		return strings.TrimPrefix(stmt.Route, "/")
+	}
+
+	return strings.ToUpper(strings.TrimSuffix(fields[0], ";"))
+}
+
+// errorType - low-cardinality error description
+func errorType(err error) string {
+	switch {
+	case errors.Is(err, ksqlErrors.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ksqlErrors.ErrAlreadyExists):
+		return "already_exists"
+	case errors.Is(err, ksqlErrors.ErrBadStatement):
+		return "bad_statement"
+	case errors.Is(err, ksqlErrors.ErrUnauthorized):
+		return "unauthorized"
+	case errors.Is(err, ksqlErrors.ErrServerUnavailable):
+		return "unavailable"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	}
+
+	return "other"
+}
+
+var (
+	_ shared.Interceptor = new(Interceptor)
+)
@@ -0,0 +1,122 @@
+package otelksql
+
+import (
+	"context"
+	ksqlErrors "github.com/gulfstream-h/ksql/errors"
+	"github.com/gulfstream-h/ksql/shared"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_InterceptorSpan(t *testing.T) {
+	const (
+		query = "SELECT * FROM USERS WHERE EMAIL = 'john@example.com' AND AGE > 18;"
+	)
+
+	testcases := []struct {
+		name       string
+		opts       []Option
+		outcome    shared.Outcome
+		wantText   string
+		wantAttrs  []attribute.KeyValue
+		wantStatus codes.Code
+	}{
+		{
+			name:     "Statement text is redacted by default",
+			outcome:  shared.Outcome{Status: http.StatusOK, Rows: 3},
+			wantText: "SELECT * FROM USERS WHERE EMAIL = '?' AND AGE > ?;",
+			wantAttrs: []attribute.KeyValue{
+				attribute.String("db.operation.name", "SELECT"),
+				attribute.Int("http.response.status_code", http.StatusOK),
+				attribute.Int64("db.response.returned_rows", 3),
+			},
+			wantStatus: codes.Unset,
+		},
+		{
+			name:       "Raw statement text is opt-in",
+			opts:       []Option{WithRawStatementText()},
+			outcome:    shared.Outcome{Status: http.StatusOK},
+			wantText:   query,
+			wantStatus: codes.Unset,
+		},
+		{
+			name:       "Statement text is omitted",
+			opts:       []Option{WithoutStatementText(), WithRawStatementText()},
+			outcome:    shared.Outcome{Status: http.StatusOK},
+			wantStatus: codes.Unset,
+		},
+		{
+			name: "Server error",
+			outcome: shared.Outcome{
+				Status: http.StatusBadRequest,
+				Err:    &ksqlErrors.KsqlError{StatusCode: http.StatusBadRequest, Code: ksqlErrors.CodeBadStatement},
+			},
+			wantText: "SELECT * FROM USERS WHERE EMAIL = '?' AND AGE > ?;",
+			wantAttrs: []attribute.KeyValue{
+				attribute.Int("http.response.status_code", http.StatusBadRequest),
+				attribute.String("error.type", "bad_statement"),
+			},
+			wantStatus: codes.Error,
+		},
+		{
+			name:       "Cancelled query is not an error",
+			outcome:    shared.Outcome{Status: http.StatusOK, Err: context.Canceled},
+			wantText:   "SELECT * FROM USERS WHERE EMAIL = '?' AND AGE > ?;",
+			wantStatus: codes.Unset,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				recorder = tracetest.NewSpanRecorder()
+				provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+				stmt     = &shared.Statement{
+					Route:  "/query-stream",
+					Query:  query,
+					Host:   "localhost:8088",
+					Header: make(http.Header),
+					Start:  time.Now(),
+				}
+			)
+
+			interceptor := New(append([]Option{
+				WithTracerProvider(provider),
+				WithPropagator(propagation.TraceContext{}),
+			}, tc.opts...)...)
+
+			ctx := interceptor.Before(context.Background(), stmt)
+			assert.True(t, trace.SpanFromContext(ctx).SpanContext().IsValid())
+			assert.NotEmpty(t, stmt.Header.Get("traceparent"))
+
+			interceptor.After(ctx, stmt, tc.outcome)
+
+			spans := recorder.Ended()
+			assert.Len(t, spans, 1)
+
+			span := spans[0]
+			assert.Equal(t, "SELECT", span.Name())
+			assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+			assert.Equal(t, tc.wantStatus, span.Status().Code)
+
+			attrs := attribute.NewSet(span.Attributes()...)
+
+			text, ok := attrs.Value("db.query.text")
+			assert.Equal(t, len(tc.wantText) != 0, ok)
+			assert.Equal(t, tc.wantText, text.AsString())
+
+			assert.Subset(t, span.Attributes(), append(tc.wantAttrs,
+				attribute.String("db.system.name", dbSystem),
+				attribute.String("server.address", "localhost:8088"),
+			))
+		})
+	}
+}
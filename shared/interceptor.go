@@ -0,0 +1,42 @@
+package shared
+
+import (
+	"context"
+	"github.com/gulfstream-h/ksql/internal/util"
+	"net/http"
+	"time"
+)
+
+// Statement - request to ksqlDB server,
+// as it's seen by interceptors
+type Statement struct {
+	Route  string      // server endpoint, e.g. /ksql or /query-stream
+	Query  string      // ksql statement, empty for service routes
+	Host   string      // node of cluster, that serves request
+	Header http.Header // outgoing headers, e.g. for trace propagation
+	Start  time.Time   // moment request is sent
+}
+
+// RedactedQuery - statement with string and numeric literals
+// replaced by placeholders, so it can be exported to
+// observability backends without leaking values
+func (s *Statement) RedactedQuery() string {
+	return util.RedactStatement(s.Query)
+}
+
+// Outcome - result of statement. For query streams
+// it's reported when stream is finished
+type Outcome struct {
+	Status   int           // http status, zero if server is not reached
+	Rows     int64         // rows received by query stream
+	Err      error         // request, server or stream error
+	Duration time.Duration // time till response or end of stream
+}
+
+// Interceptor - observes every statement of client.
+// Before is called in registration order and can enrich
+// context and headers, After is called in reverse order
+type Interceptor interface {
+	Before(ctx context.Context, stmt *Statement) context.Context
+	After(ctx context.Context, stmt *Statement, outcome Outcome)
+}
@@ -21,15 +21,31 @@ type (
 	}
 )
 
+// Cache - streams and tables storage, owned by ksql client.
+// Every client keeps its own cache, as relations
+// of different clusters don't intersect
+type Cache struct {
+	Streams *RelationStorage[shared.StreamSettings]
+	Tables  *RelationStorage[shared.TableSettings]
+}
+
+// NewCache - constructor for empty relations cache
+func NewCache() *Cache {
+	return &Cache{
+		Streams: new(RelationStorage[shared.StreamSettings]),
+		Tables:  new(RelationStorage[shared.TableSettings]),
+	}
+}
+
 // FindRelationFields returns the fields of a relation (stream or table) based on its name.
 // It can be used for other DDL check-ups
-func FindRelationFields(relationName string) (map[string]schema.SearchField, error) {
-	streamSettings, exists := StreamsProjections.Get(relationName)
+func (c *Cache) FindRelationFields(relationName string) (map[string]schema.SearchField, error) {
+	streamSettings, exists := c.Streams.Get(relationName)
 	if exists {
 		return streamSettings.Schema.Map(), nil
 	}
 
-	tableSettings, exists := TablesProjections.Get(relationName)
+	tableSettings, exists := c.Tables.Get(relationName)
 	if exists {
 		return tableSettings.Schema.Map(), nil
 	}
@@ -37,11 +53,22 @@ func FindRelationFields(relationName string) (map[string]schema.SearchField, err
 	return nil, errors.New("cannot find relation fields")
 }
 
+// FindRelationFields - searches relation in cache of default client
+func FindRelationFields(relationName string) (map[string]schema.SearchField, error) {
+	return DefaultCache.FindRelationFields(relationName)
+}
+
 var (
 	ReflectionFlag bool
 
 	StreamsProjections RelationStorage[shared.StreamSettings]
 	TablesProjections  RelationStorage[shared.TableSettings]
+
+	// DefaultCache - cache of default client, backed by package projections
+	DefaultCache = &Cache{
+		Streams: &StreamsProjections,
+		Tables:  &TablesProjections,
+	}
 )
 
 // Get - returns cached settings value
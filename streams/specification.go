@@ -7,7 +7,6 @@ import (
 	"github.com/gulfstream-h/ksql/consts"
 	"github.com/gulfstream-h/ksql/database"
 	libErrors "github.com/gulfstream-h/ksql/errors"
-	"github.com/gulfstream-h/ksql/internal/kernel/network"
 	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dao"
 	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dto"
 	"github.com/gulfstream-h/ksql/internal/schema"
@@ -16,18 +15,15 @@ import (
 	"github.com/gulfstream-h/ksql/kinds"
 	"github.com/gulfstream-h/ksql/ksql"
 	"github.com/gulfstream-h/ksql/shared"
-	"github.com/gulfstream-h/ksql/static"
 	jsoniter "github.com/json-iterator/go"
-	"log/slog"
-	"strings"
-
-	"net/http"
+	"slices"
 )
 
 // Stream - is full-functional type,
 // providing all ksql-supported operations
 // via referred to type functions calls
 type Stream[S any] struct {
+	client       *database.Client
 	Name         string
 	partitions   int
 	remoteSchema schema.LintedFields
@@ -36,16 +32,16 @@ type Stream[S any] struct {
 
 // ListStreams - responses with all streams list
 // in the current ksqlDB instance
-func ListStreams(ctx context.Context) (dto.ShowStreams, error) {
+func ListStreams(
+	ctx context.Context,
+	opts ...database.Option,
+) (dto.ShowStreams, error) {
+
+	client := database.ClientOf(opts...)
 
 	query := util.MustNoError(ksql.List(ksql.STREAM).Expression)
 
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+	pipeline, err := client.Perform(ctx, query, opts...)
 	if err != nil {
 		err = fmt.Errorf("cannot perform request: %w", err)
 		return dto.ShowStreams{}, err
@@ -77,16 +73,21 @@ func ListStreams(ctx context.Context) (dto.ShowStreams, error) {
 }
 
 // Describe - responses with stream description
-func Describe(ctx context.Context, stream string) (dto.RelationDescription, error) {
+func Describe(
+	ctx context.Context,
+	stream string,
+	opts ...database.Option,
+) (dto.RelationDescription, error) {
+
+	client := database.ClientOf(opts...)
 	query := util.MustNoError(ksql.Describe(ksql.STREAM, stream).Expression)
 
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+	pipeline, err := client.Perform(ctx, query, opts...)
 	if err != nil {
+		if errors.Is(err, libErrors.ErrNotFound) {
+			return dto.RelationDescription{}, errors.Join(libErrors.ErrStreamDoesNotExist, err)
+		}
+
 		err = fmt.Errorf("cannot perform request: %w", err)
 		return dto.RelationDescription{}, err
 	}
@@ -102,11 +103,6 @@ func Describe(ctx context.Context, stream string) (dto.RelationDescription, erro
 		var (
 			describe []dao.DescribeResponse
 		)
-		slog.Info("response", "formatted", string(val))
-
-		if strings.Contains(string(val), "Could not find STREAM/TABLE") {
-			return dto.RelationDescription{}, libErrors.ErrStreamDoesNotExist
-		}
 
 		if err = jsoniter.Unmarshal(val, &describe); err != nil {
 			err = errors.Join(libErrors.ErrUnserializableResponse, err)
@@ -123,16 +119,17 @@ func Describe(ctx context.Context, stream string) (dto.RelationDescription, erro
 
 // Drop - drops stream from ksqlDB instance
 // with parent topic
-func Drop(ctx context.Context, stream string) error {
+func Drop(
+	ctx context.Context,
+	stream string,
+	opts ...database.Option,
+) error {
+
+	client := database.ClientOf(opts...)
 
 	query := util.MustNoError(ksql.Drop(ksql.STREAM, stream).Expression)
 
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+	pipeline, err := client.Perform(ctx, query, opts...)
 	if err != nil {
 		return fmt.Errorf("cannot perform request: %w", err)
 	}
@@ -149,8 +146,6 @@ func Drop(ctx context.Context, stream string) error {
 			drop []dao.DropInfo
 		)
 
-		slog.Debug("received from pipiline", slog.String("val", string(val)))
-
 		if err = jsoniter.Unmarshal(val, &drop); err != nil {
 			return fmt.Errorf("cannot unmarshal drop response: %w", err)
 		}
@@ -174,10 +169,13 @@ func Drop(ctx context.Context, stream string) error {
 // with ksql Description - function returns detailed error
 func GetStream[S any](
 	ctx context.Context,
-	stream string) (*Stream[S], error) {
+	stream string,
+	opts ...database.Option,
+) (*Stream[S], error) {
 
 	var (
-		s S
+		s      S
+		client = database.ClientOf(opts...)
 	)
 
 	scheme, err := schema.NativeStructRepresentation(stream, s)
@@ -186,10 +184,11 @@ func GetStream[S any](
 	}
 
 	streamInstance := &Stream[S]{
+		client:       client,
 		Name:         stream,
 		remoteSchema: scheme,
 	}
-	desc, err := Describe(ctx, stream)
+	desc, err := Describe(ctx, stream, database.WithClient(client))
 	if err != nil {
 		if errors.Is(err, libErrors.ErrStreamDoesNotExist) || len(desc.Fields) == 0 {
 			return nil, err
@@ -218,10 +217,12 @@ func CreateStream[S any](
 	ctx context.Context,
 	streamName string,
 	settings shared.StreamSettings,
+	opts ...database.Option,
 ) (*Stream[S], error) {
 
 	var (
-		s S
+		s      S
+		client = database.ClientOf(opts...)
 	)
 
 	err := settings.Validate()
@@ -249,12 +250,7 @@ func CreateStream[S any](
 		With(metadata).
 		Expression()
 
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+	pipeline, err := client.Perform(ctx, query, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("cannot perform request: %w", err)
 	}
@@ -271,11 +267,6 @@ func CreateStream[S any](
 			create []dao.CreateRelationResponse
 		)
 
-		slog.Debug(
-			"received from create stream",
-			slog.String("value", string(val)),
-		)
-
 		if err = jsoniter.Unmarshal(val, &create); err != nil {
 			return nil, fmt.Errorf("cannot unmarshal create response: %w", err)
 		}
@@ -290,9 +281,10 @@ func CreateStream[S any](
 			return nil, fmt.Errorf("unsuccesful respose. msg: %s", status.CommandStatus.Message)
 		}
 
-		static.StreamsProjections.Set(streamName, settings, rmSchema)
+		client.Cache().Streams.Set(streamName, settings, rmSchema)
 
 		return &Stream[S]{
+			client:       client,
 			Name:         streamName,
 			partitions:   settings.Partitions,
 			remoteSchema: rmSchema,
@@ -307,29 +299,35 @@ func CreateStreamAsSelect[S any](
 	ctx context.Context,
 	streamName string,
 	settings shared.StreamSettings,
-	selectBuilder ksql.SelectBuilder) (*Stream[S], error) {
+	selectBuilder ksql.SelectBuilder,
+	opts ...database.Option,
+) (*Stream[S], error) {
 
 	var (
-		s S
+		s      S
+		client = database.ClientOf(opts...)
 	)
 	if selectBuilder == nil {
 		return nil, errors.New("select builder cannot be nil")
 	}
 
+	// builder is checked with relations of client
+	selectBuilder.Reflect(client.ReflectionCache())
+
 	fields := selectBuilder.Returns()
 
 	if len(fields.Map()) == 0 {
 		return nil, errors.New("select builder must return at least one field")
 	}
 
-	if static.ReflectionFlag {
+	if client.Reflection() {
 		err := report.ReflectionReportNative(s, streamName, fields)
 		if err != nil {
 			return nil, fmt.Errorf("reflection report native: %w", err)
 		}
 
 		for relName, rel := range selectBuilder.RelationReport() {
-			err = report.ReflectionReportRemote(relName, rel.Map())
+			err = report.ReflectionReportRemote(client.Cache(), relName, rel.Map())
 			if err != nil {
 				return nil, fmt.Errorf("reflection report remote (name: %s, relation: %s): %w", relName, rel, err)
 			}
@@ -348,12 +346,7 @@ func CreateStreamAsSelect[S any](
 		return nil, fmt.Errorf("build create query: %w", err)
 	}
 
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+	pipeline, err := client.Perform(ctx, query, opts...)
 	if err != nil {
 		err = fmt.Errorf("cannot perform request: %w", err)
 		return nil, fmt.Errorf("cannot perform request: %w", err)
@@ -372,7 +365,6 @@ func CreateStreamAsSelect[S any](
 		)
 
 		if err := jsoniter.Unmarshal(val, &create); err != nil {
-			slog.Debug("raw received", slog.String("raw", string(val)))
 			return nil, fmt.Errorf("cannot unmarshal create response: %w", err)
 		}
 
@@ -386,9 +378,10 @@ func CreateStreamAsSelect[S any](
 			return nil, fmt.Errorf("unsuccesful respose. msg: %s", status.CommandStatus.Message)
 		}
 
-		static.StreamsProjections.Set(streamName, settings, fields)
+		client.Cache().Streams.Set(streamName, settings, fields)
 
 		return &Stream[S]{
+			client:       client,
 			partitions:   settings.Partitions,
 			Name:         streamName,
 			remoteSchema: fields,
@@ -400,6 +393,7 @@ func CreateStreamAsSelect[S any](
 func (s *Stream[S]) Insert(
 	ctx context.Context,
 	val S,
+	opts ...database.Option,
 ) error {
 	query, err := ksql.
 		Insert(ksql.STREAM, s.Name).
@@ -410,12 +404,7 @@ func (s *Stream[S]) Insert(
 		return fmt.Errorf("construct query: %w", err)
 	}
 
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+	pipeline, err := s.client.Perform(ctx, query, opts...)
 	if err != nil {
 		return fmt.Errorf("cannot perform request: %w", err)
 	}
@@ -449,9 +438,10 @@ func (s *Stream[S]) Insert(
 func (s *Stream[S]) InsertRow(
 	ctx context.Context,
 	fields ksql.Row,
+	opts ...database.Option,
 ) error {
 
-	if static.ReflectionFlag {
+	if s.client.Reflection() {
 		scheme := s.remoteSchema
 		relationCachedFields := scheme.Map()
 		for key, value := range fields {
@@ -471,12 +461,7 @@ func (s *Stream[S]) InsertRow(
 		return fmt.Errorf("build insert query: %w", err)
 	}
 
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+	pipeline, err := s.client.Perform(ctx, query, opts...)
 	if err != nil {
 		return fmt.Errorf("cannot perform request: %w", err)
 	}
@@ -494,10 +479,6 @@ func (s *Stream[S]) InsertRow(
 		)
 
 		if err = jsoniter.Unmarshal(val, &insert); err != nil {
-			slog.Debug(
-				"unmarshal failed",
-				slog.String("raw", string(val)),
-			)
 			return fmt.Errorf("cannot unmarshal insert response: %w", err)
 		}
 
@@ -516,6 +497,7 @@ func (s *Stream[S]) InsertRow(
 func (s *Stream[S]) InsertAsSelect(
 	ctx context.Context,
 	selectBuilder ksql.SelectBuilder,
+	opts ...database.Option,
 ) error {
 
 	var (
@@ -525,7 +507,10 @@ func (s *Stream[S]) InsertAsSelect(
 		return errors.New("select builder cannot be nil")
 	}
 
-	if static.ReflectionFlag {
+	// builder is checked with relations of client
+	selectBuilder.Reflect(s.client.ReflectionCache())
+
+	if s.client.Reflection() {
 		fields := selectBuilder.Returns()
 
 		err := report.ReflectionReportNative(stream, s.Name, fields)
@@ -534,7 +519,7 @@ func (s *Stream[S]) InsertAsSelect(
 		}
 
 		for relName, rel := range selectBuilder.RelationReport() {
-			err = report.ReflectionReportRemote(relName, rel.Map())
+			err = report.ReflectionReportRemote(s.client.Cache(), relName, rel.Map())
 			if err != nil {
 				return fmt.Errorf("reflection report remote: %w", err)
 			}
@@ -546,12 +531,7 @@ func (s *Stream[S]) InsertAsSelect(
 		return fmt.Errorf("build insert query: %w", err)
 	}
 
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+	pipeline, err := s.client.Perform(ctx, query, opts...)
 	if err != nil {
 		return fmt.Errorf("cannot perform request: %w", err)
 	}
@@ -568,8 +548,6 @@ func (s *Stream[S]) InsertAsSelect(
 			insert []dao.CreateRelationResponse
 		)
 
-		slog.Info("response", "formatted", string(val))
-
 		if err = jsoniter.Unmarshal(val, &insert); err != nil {
 			return fmt.Errorf("cannot unmarshal insert response: %w", err)
 		}
@@ -583,15 +561,35 @@ func (s *Stream[S]) InsertAsSelect(
 }
 
 // SelectOnce - performs select query
-// and return only one http answer
-// After channel closes
+// and return only one http answer.
+// Query is limited to single row, so
+// server completes it after the first one
 func (s *Stream[S]) SelectOnce(
-	ctx context.Context) (S, error) {
+	ctx context.Context,
+	opts ...database.Option,
+) (S, error) {
 
 	var (
 		value S
 	)
 
+	rows, err := s.SelectMany(ctx, 1, opts...)
+	if err != nil || len(rows) == 0 {
+		return value, err
+	}
+
+	return rows[0], nil
+}
+
+// SelectMany - performs select query, which is
+// completed by server after limit rows are
+// received, and returns all of them
+func (s *Stream[S]) SelectMany(
+	ctx context.Context,
+	limit int,
+	opts ...database.Option,
+) ([]S, error) {
+
 	var (
 		fields []ksql.Field
 	)
@@ -600,35 +598,86 @@ func (s *Stream[S]) SelectOnce(
 		fields = append(fields, ksql.F(field.Name))
 	}
 
-	query, err := ksql.
-		Select(fields...).
+	builder := ksql.Select(fields...).
+		Reflect(s.client.ReflectionCache()).
 		From(ksql.Schema(s.Name, ksql.STREAM)).
-		Expression()
+		Where(database.FiltersOf(opts...)...).
+		Limit(limit)
 
+	query, err := s.lintedQuery(builder)
 	if err != nil {
-		return value, fmt.Errorf("build select query: %w", err)
+		return nil, err
 	}
 
-	valuesC, err := database.Select[S](ctx, query)
+	result, err := database.Select[S](ctx, query, s.options(opts)...)
 	if err != nil {
-		return value, err
+		return nil, err
 	}
+	defer result.Close()
+
+	var (
+		rows []S
+	)
 
-	value = <-valuesC
+	for value := range result.Values() {
+		rows = append(rows, value)
+	}
 
-	return value, nil
+	// error is nil when server completed query normally
+	return rows, result.Err()
 }
 
 // SelectWithEmit - performs
 // select with emit request
 // answer is received for every new record
-// and propagated to channel
-func (s *Stream[S]) SelectWithEmit(ctx context.Context) (
-	<-chan S, context.CancelFunc, error,
-) {
+// and propagated to channel of returned handle.
+// Handle must be closed to terminate push query on server
+func (s *Stream[S]) SelectWithEmit(
+	ctx context.Context,
+	opts ...database.Option,
+) (*database.Result[S], error) {
 
-	ctx, cancel := context.WithCancel(ctx)
+	query, err := s.emitQuery(opts)
+	if err != nil {
+		return nil, err
+	}
 
+	return database.Select[S](ctx, query, s.options(opts)...)
+}
+
+// Subscribe - performs select with emit request, which
+// is re-issued when connection is dropped. Reconnects are
+// configured with database.WithReconnectPolicy and reported
+// to database.OnReconnect handler. Handle must be closed
+// to terminate push query on server
+func (s *Stream[S]) Subscribe(
+	ctx context.Context,
+	opts ...database.Option,
+) (*database.Result[S], error) {
+
+	query, err := s.emitQuery(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return database.Subscribe[S](ctx, query, s.options(opts)...)
+}
+
+// Hub - creates hub, that shares single select with
+// emit request between many consumers. Query is
+// started with the first attached consumer
+func (s *Stream[S]) Hub(opts ...database.Option) (*database.Hub[S], error) {
+	query, err := s.emitQuery(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return database.NewHub[S](query, s.options(opts)...), nil
+}
+
+// emitQuery - builds push query over all fields of
+// stream, narrowed by filters and limit of options
+func (s *Stream[S]) emitQuery(opts []database.Option) (string, error) {
 	var (
 		fields []ksql.Field
 	)
@@ -637,19 +686,86 @@ func (s *Stream[S]) SelectWithEmit(ctx context.Context) (
 		fields = append(fields, ksql.F(field.Name))
 	}
 
-	query, err := ksql.Select(fields...).
+	return s.pushQuery(fields, opts)
+}
+
+// pushQuery - builds push query over provided fields
+func (s *Stream[S]) pushQuery(
+	fields []ksql.Field,
+	opts []database.Option,
+) (string, error) {
+
+	builder := ksql.Select(fields...).
+		Reflect(s.client.ReflectionCache()).
 		From(ksql.Schema(s.Name, ksql.STREAM)).
-		EmitChanges().
-		Expression()
+		Where(database.FiltersOf(opts...)...).
+		EmitChanges()
+
+	// push query is unbounded, unless limit is passed
+	if limit := database.LimitOf(opts...); limit > 0 {
+		builder = builder.Limit(limit)
+	}
+
+	return s.lintedQuery(builder)
+}
+
+// lintedQuery - builds select query of stream.
+// Selected and filtered fields are linted against
+// stream schema, when reflection is enabled
+func (s *Stream[S]) lintedQuery(builder ksql.SelectBuilder) (string, error) {
+	if s.client.Reflection() {
+		for relName, rel := range builder.RelationReport() {
+			err := report.ReflectionReportRemote(s.client.Cache(), relName, rel.Map())
+			if err != nil {
+				return "", fmt.Errorf("reflection report remote: %w", err)
+			}
+		}
+	}
 
+	query, err := builder.Expression()
 	if err != nil {
-		return nil, cancel, fmt.Errorf("build select query: %w", err)
+		return "", fmt.Errorf("build select query: %w", err)
 	}
 
-	valuesC, err := database.Select[S](ctx, query)
+	return query, nil
+}
+
+// Project - performs select with emit request, which
+// returns only fields of P structure. Projection must
+// be a subset of stream fields with the same types
+func Project[P any, S any](
+	ctx context.Context,
+	stream *Stream[S],
+	opts ...database.Option,
+) (*database.Result[P], error) {
+
+	var (
+		p      P
+		fields []ksql.Field
+	)
+
+	projection, err := schema.NativeStructRepresentation(stream.Name, p)
 	if err != nil {
-		return nil, cancel, err
+		return nil, err
 	}
 
-	return valuesC, cancel, nil
+	if err = stream.remoteSchema.CompareWithFields(projection.Array()); err != nil {
+		return nil, fmt.Errorf("reflection check failed: %w", err)
+	}
+
+	for _, field := range projection.Array() {
+		fields = append(fields, ksql.F(field.Name))
+	}
+
+	query, err := stream.pushQuery(fields, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return database.Select[P](ctx, query, stream.options(opts)...)
+}
+
+// options - binds call to client of stream
+func (s *Stream[S]) options(opts []database.Option) []database.Option {
+	return append(slices.Clone(opts), database.WithClient(s.client))
 }
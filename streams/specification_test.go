@@ -0,0 +1,107 @@
+package streams
+
+import (
+	"context"
+	"github.com/gulfstream-h/ksql/database"
+	"github.com/gulfstream-h/ksql/internal/kernel/network"
+	"github.com/gulfstream-h/ksql/internal/schema"
+	"github.com/gulfstream-h/ksql/ksql"
+	"github.com/gulfstream-h/ksql/shared"
+	"github.com/gulfstream-h/ksql/static"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_EmitQuery(t *testing.T) {
+	stream := &Stream[struct{}]{
+		Name:         "orders",
+		remoteSchema: schema.RemoteFieldsRepresentation("orders", map[string]string{"ID": "INT"}),
+	}
+
+	testcases := []struct {
+		name      string
+		opts      []database.Option
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "Without limit",
+			expected: "SELECT ID FROM orders EMIT CHANGES;",
+		},
+		{
+			name:     "Zero limit is unbounded",
+			opts:     []database.Option{database.WithLimit(0)},
+			expected: "SELECT ID FROM orders EMIT CHANGES;",
+		},
+		{
+			name:     "With limit",
+			opts:     []database.Option{database.WithLimit(5)},
+			expected: "SELECT ID FROM orders EMIT CHANGES LIMIT 5;",
+		},
+		{
+			name: "With filter and limit",
+			opts: []database.Option{
+				database.WithFilter(ksql.F("ID").Greater(10)),
+				database.WithLimit(1),
+			},
+			expected: "SELECT ID FROM orders WHERE ID > 10 EMIT CHANGES LIMIT 1;",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := stream.emitQuery(tc.opts)
+			assert.Equal(t, tc.expectErr, err != nil, err)
+			assert.Equal(t, tc.expected, query)
+		})
+	}
+}
+
+func Test_SelectLint(t *testing.T) {
+	var (
+		remoteSchema = schema.RemoteFieldsRepresentation("orders", map[string]string{
+			"ID":   "INT",
+			"NAME": "STRING",
+		})
+		cache = static.NewCache()
+	)
+
+	cache.Streams.Set("orders", shared.StreamSettings{}, remoteSchema)
+
+	stream := &Stream[struct {
+		ID   int    `ksql:"ID"`
+		Name string `ksql:"NAME"`
+	}]{
+		client: database.NewClient(network.New(network.Settings{
+			Hosts: []string{"http://localhost:8088"},
+		}), true, cache, nil),
+		Name:         "orders",
+		remoteSchema: remoteSchema,
+	}
+
+	badFilter := database.WithFilter(ksql.F("orders.AMOUNT").Greater(10))
+
+	t.Run("Bounded query with unknown filter field", func(t *testing.T) {
+		_, err := stream.SelectMany(context.Background(), 5, badFilter)
+		assert.ErrorContains(t, err, "AMOUNT")
+	})
+
+	t.Run("Push query with unknown filter field", func(t *testing.T) {
+		_, err := stream.emitQuery([]database.Option{badFilter})
+		assert.ErrorContains(t, err, "AMOUNT")
+	})
+
+	t.Run("Projection with unknown field", func(t *testing.T) {
+		_, err := Project[struct {
+			Amount int `ksql:"AMOUNT"`
+		}](context.Background(), stream)
+		assert.Error(t, err)
+	})
+
+	t.Run("Projection with mismatched type", func(t *testing.T) {
+		_, err := Project[struct {
+			Name int `ksql:"NAME"`
+		}](context.Background(), stream)
+		assert.Error(t, err)
+	})
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/gulfstream-h/ksql/consts"
 	"github.com/gulfstream-h/ksql/database"
 	libErrors "github.com/gulfstream-h/ksql/errors"
-	"github.com/gulfstream-h/ksql/internal/kernel/network"
 	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dao"
 	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dto"
 	"github.com/gulfstream-h/ksql/internal/schema"
@@ -16,17 +15,16 @@ import (
 	"github.com/gulfstream-h/ksql/kinds"
 	"github.com/gulfstream-h/ksql/ksql"
 	"github.com/gulfstream-h/ksql/shared"
-	"github.com/gulfstream-h/ksql/static"
 	jsoniter "github.com/json-iterator/go"
 	"log/slog"
-	"net/http"
-	"strings"
+	"slices"
 )
 
 // Table - is full-functional type,
 // providing all ksql-supported operations
 // via referred to type functions calls
 type Table[S any] struct {
+	client       *database.Client
 	Name         string
 	sourceTopic  string
 	partitions   int
@@ -36,18 +34,16 @@ type Table[S any] struct {
 
 // ListTables - responses with all tables list
 // in the current ksqlDB instance
-func ListTables(ctx context.Context) (
-	dto.ShowTables, error,
-) {
+func ListTables(
+	ctx context.Context,
+	opts ...database.Option,
+) (dto.ShowTables, error) {
+
+	client := database.ClientOf(opts...)
 
 	query := util.MustNoError(ksql.List(ksql.TABLE).Expression)
 
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+	pipeline, err := client.Perform(ctx, query, opts...)
 	if err != nil {
 		err = fmt.Errorf("cannot perform request: %w", err)
 		return dto.ShowTables{}, err
@@ -79,16 +75,21 @@ func ListTables(ctx context.Context) (
 }
 
 // Describe - responses with table description
-func Describe(ctx context.Context, table string) (dto.RelationDescription, error) {
+func Describe(
+	ctx context.Context,
+	table string,
+	opts ...database.Option,
+) (dto.RelationDescription, error) {
+
+	client := database.ClientOf(opts...)
 	query := util.MustNoError(ksql.Describe(ksql.TABLE, table).Expression)
 
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+	pipeline, err := client.Perform(ctx, query, opts...)
 	if err != nil {
+		if errors.Is(err, libErrors.ErrNotFound) {
+			return dto.RelationDescription{}, errors.Join(libErrors.ErrTableDoesNotExist, err)
+		}
+
 		err = fmt.Errorf("cannot perform request: %w", err)
 		return dto.RelationDescription{}, err
 	}
@@ -105,10 +106,6 @@ func Describe(ctx context.Context, table string) (dto.RelationDescription, error
 			describe []dao.DescribeResponse
 		)
 
-		if strings.Contains(string(val), "Could not find STREAM/TABLE") {
-			return dto.RelationDescription{}, libErrors.ErrTableDoesNotExist
-		}
-
 		if err = jsoniter.Unmarshal(val, &describe); err != nil {
 			err = errors.Join(libErrors.ErrUnserializableResponse, err)
 			return dto.RelationDescription{}, err
@@ -124,55 +121,41 @@ func Describe(ctx context.Context, table string) (dto.RelationDescription, error
 
 // Drop - drops table from ksqlDB instance
 // with parent topic
-func Drop(ctx context.Context, name string) error {
-	query := util.MustNoError(ksql.Drop(ksql.TABLE, fmt.Sprintf("%s_%s", consts.Queryable, name)).Expression)
-
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
-	if err != nil {
-		return fmt.Errorf("cannot perform request: %w", err)
-	}
-
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case val, ok := <-pipeline:
-		if !ok {
-			return libErrors.ErrMalformedResponse
-		}
-
-		var (
-			drop []dao.DropInfo
-		)
+func Drop(
+	ctx context.Context,
+	name string,
+	opts ...database.Option,
+) error {
 
-		if err = jsoniter.Unmarshal(val, &drop); err != nil {
-			slog.Debug("received from pipeline", slog.String("val", string(val)))
-			goto del
-			//return fmt.Errorf("cannot unmarshal drop response: %w", err)
-		}
+	client := database.ClientOf(opts...)
 
-		if len(drop) == 0 {
-			return errors.New("cannot drop stream")
+	// queryable projection may be already dropped
+	// or its response may be unparsable, both
+	// don't prevent dropping of the table itself
+	err := dropTable(ctx, client, fmt.Sprintf("%s_%s", consts.Queryable, name), opts)
+	if err != nil {
+		if !errors.Is(err, libErrors.ErrNotFound) &&
+			!errors.Is(err, libErrors.ErrUnserializableResponse) {
+			return err
 		}
 
-		if drop[0].CommandStatus.Status != consts.SUCCESS {
-			return fmt.Errorf("cannot drop table: %s", drop[0].CommandStatus.Status)
-		}
+		client.Logger().Debug("queryable table is not dropped", slog.String("table", name))
 	}
 
-del:
-	query = util.MustNoError(ksql.Drop(ksql.TABLE, name).Expression)
+	return dropTable(ctx, client, name, opts)
+}
 
-	pipeline, err = network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+// dropTable - performs drop statement of single table
+func dropTable(
+	ctx context.Context,
+	client *database.Client,
+	name string,
+	opts []database.Option,
+) error {
+
+	query := util.MustNoError(ksql.Drop(ksql.TABLE, name).Expression)
+
+	pipeline, err := client.Perform(ctx, query, opts...)
 	if err != nil {
 		return fmt.Errorf("cannot perform request: %w", err)
 	}
@@ -185,24 +168,24 @@ del:
 			return libErrors.ErrMalformedResponse
 		}
 
-		slog.Debug("received from pipeline", slog.String("val", string(val)))
-
-		var drop []dao.DropInfo
+		var (
+			drop []dao.DropInfo
+		)
 
 		if err = jsoniter.Unmarshal(val, &drop); err != nil {
-			return fmt.Errorf("cannot unmarshal drop response: %w", err)
+			return errors.Join(libErrors.ErrUnserializableResponse, err)
 		}
 
 		if len(drop) == 0 {
-			return errors.New("cannot drop stream")
+			return errors.New("cannot drop table")
 		}
 
 		if drop[0].CommandStatus.Status != consts.SUCCESS {
 			return fmt.Errorf("cannot drop table: %s", drop[0].CommandStatus.Status)
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
 // GetTable - gets table from ksqlDB instance
@@ -211,10 +194,13 @@ del:
 // differs from server response
 func GetTable[S any](
 	ctx context.Context,
-	table string) (*Table[S], error) {
+	table string,
+	opts ...database.Option,
+) (*Table[S], error) {
 
 	var (
-		s S
+		s      S
+		client = database.ClientOf(opts...)
 	)
 
 	scheme, err := schema.NativeStructRepresentation(table, s)
@@ -223,10 +209,11 @@ func GetTable[S any](
 	}
 
 	tableInstance := &Table[S]{
+		client:       client,
 		Name:         table,
 		remoteSchema: scheme,
 	}
-	desc, err := Describe(ctx, table)
+	desc, err := Describe(ctx, table, database.WithClient(client))
 	if err != nil {
 		if errors.Is(err, libErrors.ErrTableDoesNotExist) {
 			return nil, err
@@ -254,10 +241,13 @@ func GetTable[S any](
 func CreateTable[S any](
 	ctx context.Context,
 	tableName string,
-	settings shared.TableSettings) (*Table[S], error) {
+	settings shared.TableSettings,
+	opts ...database.Option,
+) (*Table[S], error) {
 
 	var (
-		s S
+		s      S
+		client = database.ClientOf(opts...)
 	)
 
 	rmSchema, err := schema.NativeStructRepresentation(tableName, s)
@@ -280,12 +270,7 @@ func CreateTable[S any](
 		return nil, fmt.Errorf("build create query: %w", err)
 	}
 
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+	pipeline, err := client.Perform(ctx, query, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("cannot perform request: %w", err)
 	}
@@ -302,8 +287,6 @@ func CreateTable[S any](
 			create []dao.CreateRelationResponse
 		)
 
-		slog.Debug("ksql response", "raw", string(val))
-
 		if err = jsoniter.Unmarshal(val, &create); err != nil {
 			return nil, fmt.Errorf("cannot unmarshal create response: %w", err)
 		}
@@ -318,11 +301,11 @@ func CreateTable[S any](
 			return nil, fmt.Errorf("unsuccesful respose. msg: %s", status.CommandStatus.Message)
 		}
 
-		static.TablesProjections.Set(tableName, settings, rmSchema)
+		client.Cache().Tables.Set(tableName, settings, rmSchema)
 
 		query = fmt.Sprintf("CREATE TABLE QUERYABLE_%s AS SELECT * FROM %s;", tableName, tableName)
 
-		pipeline, err = network.Net.Perform(ctx, http.MethodPost, query, network.ShortPolling{})
+		pipeline, err = client.Perform(ctx, query, opts...)
 		if err != nil {
 			return nil, fmt.Errorf("cannot perform request: %w", err)
 		}
@@ -355,6 +338,7 @@ func CreateTable[S any](
 		}
 
 		return &Table[S]{
+			client:       client,
 			Name:         tableName,
 			sourceTopic:  settings.SourceTopic,
 			partitions:   settings.Partitions,
@@ -371,30 +355,35 @@ func CreateTableAsSelect[S any](
 	tableName string,
 	settings shared.TableSettings,
 	selectBuilder ksql.SelectBuilder,
+	opts ...database.Option,
 ) (*Table[S], error) {
 
 	var (
-		s S
+		s      S
+		client = database.ClientOf(opts...)
 	)
 
 	if selectBuilder == nil {
 		return nil, errors.New("select builder cannot be nil")
 	}
 
+	// builder is checked with relations of client
+	selectBuilder.Reflect(client.ReflectionCache())
+
 	fields := selectBuilder.Returns()
 
 	if len(fields.Map()) == 0 {
 		return nil, errors.New("select builder must return at least one field")
 	}
 
-	if static.ReflectionFlag {
+	if client.Reflection() {
 		err := report.ReflectionReportNative(s, tableName, fields)
 		if err != nil {
 			return nil, fmt.Errorf("reflection report native: %w", err)
 		}
 
 		for relName, rel := range selectBuilder.RelationReport() {
-			err = report.ReflectionReportRemote(relName, rel.Map())
+			err = report.ReflectionReportRemote(client.Cache(), relName, rel.Map())
 			if err != nil {
 				return nil, fmt.Errorf("reflection report remote: %w", err)
 			}
@@ -416,12 +405,7 @@ func CreateTableAsSelect[S any](
 		return nil, fmt.Errorf("build create query: %w", err)
 	}
 
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+	pipeline, err := client.Perform(ctx, query, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("cannot perform request: %w", err)
 	}
@@ -439,7 +423,6 @@ func CreateTableAsSelect[S any](
 		)
 
 		if err = jsoniter.Unmarshal(val, &create); err != nil {
-			slog.Debug("ksql response", "raw", string(val))
 			return nil, fmt.Errorf("cannot unmarshal create response: %w", err)
 		}
 
@@ -453,9 +436,11 @@ func CreateTableAsSelect[S any](
 			return nil, fmt.Errorf("unsuccesful respose. msg: %s", status.CommandStatus.Message)
 		}
 
-		static.TablesProjections.Set(tableName, settings, fields)
+		client.Cache().Tables.Set(tableName, settings, fields)
 
 		return &Table[S]{
+			client:       client,
+			Name:         tableName,
 			sourceTopic:  settings.SourceTopic,
 			partitions:   settings.Partitions,
 			remoteSchema: fields,
@@ -469,6 +454,7 @@ func CreateTableAsSelect[S any](
 // After channel closes
 func (s *Table[S]) SelectOnce(
 	ctx context.Context,
+	opts ...database.Option,
 ) (S, error) {
 
 	var (
@@ -485,6 +471,7 @@ func (s *Table[S]) SelectOnce(
 
 	query, err :=
 		ksql.Select(fields...).
+			Reflect(s.client.ReflectionCache()).
 			From(ksql.Schema(
 				fmt.Sprintf("%s_%s", consts.Queryable, s.Name), ksql.TABLE),
 			).Expression()
@@ -492,26 +479,219 @@ func (s *Table[S]) SelectOnce(
 		return value, fmt.Errorf("build select query: %w", err)
 	}
 
-	valuesC, err := database.Select[S](ctx, query)
+	result, err := database.Select[S](ctx, query, s.options(opts)...)
 	if err != nil {
 		return value, err
 	}
+	defer result.Close()
 
-	value = <-valuesC
+	value, ok := <-result.Values()
+	if !ok {
+		// query is finished without rows, error
+		// is nil when server completed it normally
+		return value, result.Err()
+	}
 
 	return value, nil
 }
 
+// Get - performs pull query by primary key, that is marked
+// with primary tag option. Returns ErrRowNotFound, if
+// table has no row with such key
+func (s *Table[S]) Get(
+	ctx context.Context,
+	key any,
+	opts ...database.Option,
+) (S, error) {
+
+	var (
+		value S
+	)
+
+	primary, err := s.primaryKey()
+	if err != nil {
+		return value, err
+	}
+
+	rows, err := s.query(ctx, []ksql.Conditional{ksql.F(primary).Equal(key)}, opts)
+	if err != nil {
+		return value, err
+	}
+
+	if len(rows) == 0 {
+		return value, libErrors.ErrRowNotFound
+	}
+
+	return rows[0], nil
+}
+
+// GetMany - performs pull query by several primary keys.
+// Rows of missing keys are omitted from result. Call
+// options are taken from client of table, lookups with
+// options are performed with Query and database.WithFilter
+func (s *Table[S]) GetMany(
+	ctx context.Context,
+	keys ...any,
+) ([]S, error) {
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	primary, err := s.primaryKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.query(ctx, []ksql.Conditional{ksql.F(primary).In(keys...)}, nil)
+}
+
+// Query - performs pull query with WHERE conditions, passed
+// with database.WithFilter option, and returns every matching
+// row. Conditions, that don't restrict primary key, require
+// table scans to be enabled with ksql.query.pull.table.scan.enabled
+// property, passed with database.WithProperty option
+func (s *Table[S]) Query(
+	ctx context.Context,
+	opts ...database.Option,
+) ([]S, error) {
+	return s.query(ctx, nil, opts)
+}
+
+// query - performs pull query over queryable table.
+// Conditions are narrowed by filters of options
+func (s *Table[S]) query(
+	ctx context.Context,
+	conditions []ksql.Conditional,
+	opts []database.Option,
+) ([]S, error) {
+
+	query, err := s.pullQuery(conditions, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := database.Select[S](ctx, query, s.options(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	var (
+		rows []S
+	)
+
+	for value := range result.Values() {
+		rows = append(rows, value)
+	}
+
+	if err = result.Err(); err != nil {
+		return rows, err
+	}
+
+	return rows, nil
+}
+
+// pullQuery - builds pull query over all fields of table
+func (s *Table[S]) pullQuery(
+	conditions []ksql.Conditional,
+	opts []database.Option,
+) (string, error) {
+
+	var (
+		fields []ksql.Field
+	)
+
+	for _, field := range s.remoteSchema.Array() {
+		fields = append(fields, ksql.F(field.Name))
+	}
+
+	query, err := ksql.Select(fields...).
+		Reflect(s.client.ReflectionCache()).
+		From(ksql.Schema(
+			fmt.Sprintf("%s_%s", consts.Queryable, s.Name), ksql.TABLE),
+		).
+		Where(append(slices.Clone(conditions), database.FiltersOf(opts...)...)...).
+		Expression()
+	if err != nil {
+		return "", fmt.Errorf("build select query: %w", err)
+	}
+
+	return query, nil
+}
+
+// primaryKey - returns column, that is tagged as
+// primary in structure of table
+func (s *Table[S]) primaryKey() (string, error) {
+	var (
+		value S
+	)
+
+	fields, err := schema.NativeStructRepresentation(s.Name, value)
+	if err != nil {
+		return "", err
+	}
+
+	for _, field := range fields.Array() {
+		if field.IsPrimary {
+			return field.Name, nil
+		}
+	}
+
+	return "", libErrors.ErrMissingPrimaryKey
+}
+
 // SelectWithEmit - performs
 // select with emit request
 // answer is received for every new record
-// and propagated to channel
-func (s *Table[S]) SelectWithEmit(ctx context.Context) (
-	<-chan S, context.CancelFunc, error,
-) {
+// and propagated to channel of returned handle.
+// Handle must be closed to terminate push query on server
+func (s *Table[S]) SelectWithEmit(
+	ctx context.Context,
+	opts ...database.Option,
+) (*database.Result[S], error) {
+
+	query, err := s.emitQuery(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return database.Select[S](ctx, query, s.options(opts)...)
+}
+
+// Subscribe - performs select with emit request, which
+// is re-issued when connection is dropped. Reconnects are
+// configured with database.WithReconnectPolicy and reported
+// to database.OnReconnect handler. Handle must be closed
+// to terminate push query on server
+func (s *Table[S]) Subscribe(
+	ctx context.Context,
+	opts ...database.Option,
+) (*database.Result[S], error) {
+
+	query, err := s.emitQuery(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return database.Subscribe[S](ctx, query, s.options(opts)...)
+}
+
+// Hub - creates hub, that shares single select with
+// emit request between many consumers. Query is
+// started with the first attached consumer
+func (s *Table[S]) Hub(opts ...database.Option) (*database.Hub[S], error) {
+	query, err := s.emitQuery(opts)
+	if err != nil {
+		return nil, err
+	}
 
-	ctx, cancel := context.WithCancel(ctx)
+	return database.NewHub[S](query, s.options(opts)...), nil
+}
 
+// emitQuery - builds push query over all fields of
+// table, narrowed by filters and limit of options
+func (s *Table[S]) emitQuery(opts []database.Option) (string, error) {
 	var (
 		fields []ksql.Field
 	)
@@ -520,21 +700,29 @@ func (s *Table[S]) SelectWithEmit(ctx context.Context) (
 		fields = append(fields, ksql.F(field.Name))
 	}
 
-	query, err := ksql.Select(fields...).
+	builder := ksql.Select(fields...).
+		Reflect(s.client.ReflectionCache()).
 		From(ksql.Schema(
 			fmt.Sprintf("%s_%s",
 				consts.Queryable, s.Name), ksql.TABLE),
 		).
-		EmitChanges().
-		Expression()
-	if err != nil {
-		return nil, cancel, fmt.Errorf("build select query: %w", err)
+		Where(database.FiltersOf(opts...)...).
+		EmitChanges()
+
+	// push query is unbounded, unless limit is passed
+	if limit := database.LimitOf(opts...); limit > 0 {
+		builder = builder.Limit(limit)
 	}
 
-	valuesC, err := database.Select[S](ctx, query)
+	query, err := builder.Expression()
 	if err != nil {
-		return nil, cancel, err
+		return "", fmt.Errorf("build select query: %w", err)
 	}
 
-	return valuesC, cancel, nil
+	return query, nil
+}
+
+// options - binds call to client of table
+func (s *Table[S]) options(opts []database.Option) []database.Option {
+	return append(slices.Clone(opts), database.WithClient(s.client))
 }
@@ -0,0 +1,100 @@
+package tables
+
+import (
+	"github.com/gulfstream-h/ksql/database"
+	"github.com/gulfstream-h/ksql/internal/schema"
+	"github.com/gulfstream-h/ksql/ksql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_EmitQuery(t *testing.T) {
+	table := &Table[struct{}]{
+		Name:         "orders",
+		remoteSchema: schema.RemoteFieldsRepresentation("orders", map[string]string{"ID": "INT"}),
+	}
+
+	testcases := []struct {
+		name      string
+		opts      []database.Option
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "Without limit",
+			expected: "SELECT ID FROM QUERYABLE_orders EMIT CHANGES;",
+		},
+		{
+			name:     "Zero limit is unbounded",
+			opts:     []database.Option{database.WithLimit(0)},
+			expected: "SELECT ID FROM QUERYABLE_orders EMIT CHANGES;",
+		},
+		{
+			name:     "With limit",
+			opts:     []database.Option{database.WithLimit(5)},
+			expected: "SELECT ID FROM QUERYABLE_orders EMIT CHANGES LIMIT 5;",
+		},
+		{
+			name: "With filter and limit",
+			opts: []database.Option{
+				database.WithFilter(ksql.F("ID").Greater(10)),
+				database.WithLimit(1),
+			},
+			expected: "SELECT ID FROM QUERYABLE_orders WHERE ID > 10 EMIT CHANGES LIMIT 1;",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := table.emitQuery(tc.opts)
+			assert.Equal(t, tc.expectErr, err != nil, err)
+			assert.Equal(t, tc.expected, query)
+		})
+	}
+}
+
+func Test_PullQuery(t *testing.T) {
+	table := &Table[struct{}]{
+		Name:         "orders",
+		remoteSchema: schema.RemoteFieldsRepresentation("orders", map[string]string{"ID": "INT"}),
+	}
+
+	testcases := []struct {
+		name       string
+		conditions []ksql.Conditional
+		opts       []database.Option
+		expected   string
+	}{
+		{
+			name:     "Without conditions",
+			expected: "SELECT ID FROM QUERYABLE_orders;",
+		},
+		{
+			name:       "Key lookup",
+			conditions: []ksql.Conditional{ksql.F("ID").In(1, 2, 3)},
+			expected:   "SELECT ID FROM QUERYABLE_orders WHERE ID IN (1, 2, 3);",
+		},
+		{
+			name: "Filters of options",
+			opts: []database.Option{
+				database.WithFilter(ksql.F("ID").Greater(10)),
+				database.WithFilter(ksql.F("ID").Less(20)),
+			},
+			expected: "SELECT ID FROM QUERYABLE_orders WHERE ID > 10 AND ID < 20;",
+		},
+		{
+			name:       "Key lookup narrowed by filter",
+			conditions: []ksql.Conditional{ksql.F("ID").Equal(1)},
+			opts:       []database.Option{database.WithFilter(ksql.F("ID").Less(20))},
+			expected:   "SELECT ID FROM QUERYABLE_orders WHERE ID = 1 AND ID < 20;",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := table.pullQuery(tc.conditions, tc.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, query)
+		})
+	}
+}
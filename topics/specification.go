@@ -4,25 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/gulfstream-h/ksql/database"
 	libErrors "github.com/gulfstream-h/ksql/errors"
-	"github.com/gulfstream-h/ksql/internal/kernel/network"
 	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dao"
 	"github.com/gulfstream-h/ksql/internal/kernel/protocol/dto"
 	"github.com/gulfstream-h/ksql/ksql"
 	jsoniter "github.com/json-iterator/go"
-	"net/http"
 )
 
 // ListTopics - returns all existing topics with metadata
-func ListTopics(ctx context.Context) (dto.ShowTopics, error) {
+func ListTopics(
+	ctx context.Context,
+	opts ...database.Option,
+) (dto.ShowTopics, error) {
+
 	query, _ := ksql.List(ksql.TOPIC).Expression()
 
-	pipeline, err := network.Net.Perform(
-		ctx,
-		http.MethodPost,
-		query,
-		&network.ShortPolling{},
-	)
+	pipeline, err := database.ClientOf(opts...).Perform(ctx, query, opts...)
 	if err != nil {
 		err = fmt.Errorf("cannot perform request: %w", err)
 		return dto.ShowTopics{}, err